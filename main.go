@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
@@ -9,8 +10,11 @@ import (
 	"time"
 
 	"github.com/AlexTLDR/mycv.quest/pkg/config"
+	"github.com/AlexTLDR/mycv.quest/pkg/drafts"
+	"github.com/AlexTLDR/mycv.quest/pkg/export"
 	"github.com/AlexTLDR/mycv.quest/pkg/generator"
 	"github.com/AlexTLDR/mycv.quest/pkg/server"
+	"github.com/AlexTLDR/mycv.quest/pkg/typst"
 )
 
 func main() {
@@ -18,14 +22,88 @@ func main() {
 	listFlag := flag.Bool("list", false, "List available templates")
 	serveFlag := flag.Bool("serve", false, "Start web server")
 	portFlag := flag.String("port", "8080", "Port to serve on")
+	publicURLFlag := flag.String("public-url", "", "Address the server is reached at, used in links to public profiles (default http://localhost:<port>)")
+	inputFlag := flag.String("input", "", "JSON Resume file to generate the CV from")
+	formatFlag := flag.String("format", "pdf", "Output format (pdf, png, svg, or text, markdown and docx with -input)")
+	dpiFlag := flag.Int("dpi", generator.DefaultDPI, "Resolution of PNG pages")
+	pageFlag := flag.Int("page", 0, "Page to write as a png or svg image (0 zips every page)")
+	templatesFlag := flag.String("templates", "templates", "Directory containing template manifests")
+	draftsFlag := flag.String("drafts", "", "Directory to save drafts in (kept in memory if empty)")
+	workDirFlag := flag.String("workdir", "temp", "Directory in which each generation gets its own work directory")
+	defaults := generator.DefaultSchedulerConfig()
+	workersFlag := flag.Int("workers", defaults.Workers, "Typst compilations that may run at once")
+	queueFlag := flag.Int("queue", defaults.QueueSize, "Compilations that may wait for a worker before requests are turned away")
+	compileTimeoutFlag := flag.Duration("compile-timeout", defaults.Timeout, "Time limit of a single Typst compilation")
+	packagesFlag := flag.String("packages", "packages", "Directory of vendored Typst packages templates import")
+	vendorFlag := flag.Bool("vendor-packages", false, "Download the Typst packages missing from -packages and exit")
+	fontsFlag := flag.String("fonts", "fonts", "Directory of the fonts Typst uses instead of system fonts")
+	offlineFlag := flag.Bool("offline", false, "Refuse to start when a Typst package is missing from -packages")
+	cacheSizeFlag := flag.Int64("cache-size", generator.DefaultCacheSize>>20, "Megabytes of compiled PDFs kept for identical inputs (0 disables the cache)")
 	flag.Parse()
 
 	// Initialize configuration and generator
-	cfg := config.NewConfig()
-	gen := generator.New(cfg)
+	cfg, err := config.NewConfig(*templatesFlag)
+	if err != nil {
+		log.Fatalf("Error loading templates: %v", err)
+	}
+	cfg.WorkDir = *workDirFlag
+	cfg.PackageDir = *packagesFlag
+	cfg.FontDir = *fontsFlag
+	scheduler, err := generator.NewScheduler(generator.SchedulerConfig{
+		Workers:   *workersFlag,
+		QueueSize: *queueFlag,
+		Timeout:   *compileTimeoutFlag,
+	})
+	if err != nil {
+		log.Fatalf("Error configuring compile workers: %v", err)
+	}
+	gen := generator.NewWithOptions(cfg, generator.Options{
+		Scheduler: scheduler,
+		Cache:     generator.NewRenderCache(*cacheSizeFlag << 20),
+	})
+
+	if *vendorFlag {
+		fetched, err := gen.VendorPackages(context.Background(), typst.DefaultRegistry)
+		if err != nil {
+			log.Fatalf("Error vendoring packages: %v", err)
+		}
+		for _, pkg := range fetched {
+			fmt.Printf("Vendored %s\n", pkg)
+		}
+		fmt.Printf("Vendored %d packages into %s\n", len(fetched), *packagesFlag)
+		return
+	}
+
+	if *listFlag {
+		gen.ListTemplates()
+		return
+	}
 
 	if *serveFlag {
-		srv := server.New(gen)
+		checkTypst(gen, *packagesFlag, *offlineFlag)
+
+		// Work directories left by a crash are never cleaned up otherwise
+		if removed, err := gen.SweepWorkDirs(); err != nil {
+			log.Printf("Error removing stale work directories: %v", err)
+		} else if removed > 0 {
+			fmt.Printf("Removed %d stale work directories\n", removed)
+		}
+
+		var store drafts.Store = drafts.NewMemoryStore()
+		if *draftsFlag != "" {
+			if store, err = drafts.NewFileStore(*draftsFlag); err != nil {
+				log.Fatalf("Error opening drafts: %v", err)
+			}
+		}
+
+		srv := server.New(gen, store)
+		publicURL := *publicURLFlag
+		if publicURL == "" {
+			publicURL = "http://localhost:" + *portFlag
+		}
+		if err := srv.SetPublicURL(publicURL); err != nil {
+			log.Fatalf("Error: %v", err)
+		}
 		srv.SetupRoutes()
 		fmt.Printf("Starting server on http://localhost:%s\n", *portFlag)
 
@@ -40,12 +118,54 @@ func main() {
 		return
 	}
 
-	if *listFlag {
-		gen.ListTemplates()
+	// Plain text, Markdown and DOCX are written from the CV alone, without Typst
+	if textFormat, err := export.ParseFormat(*formatFlag); err == nil {
+		if *inputFlag == "" {
+			log.Fatalf("Error: -format %s needs a JSON Resume file given with -input", textFormat)
+		}
+		if err := gen.ExportFromFile(*templateFlag, *inputFlag, textFormat); err != nil {
+			log.Fatalf("Error exporting CV: %v", err)
+		}
 		return
 	}
 
-	if err := gen.Generate(context.Background(), *templateFlag); err != nil {
+	format, err := generator.ParseFormat(*formatFlag)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	checkTypst(gen, *packagesFlag, *offlineFlag)
+	output := generator.Output{Format: format, DPI: *dpiFlag, Page: *pageFlag}
+	if *inputFlag != "" {
+		err = gen.GenerateFromFile(context.Background(), *templateFlag, *inputFlag, output)
+	} else {
+		err = gen.Generate(context.Background(), *templateFlag, output)
+	}
+	if err != nil {
+		var compileErr *generator.CompileError
+		if errors.As(err, &compileErr) {
+			log.Fatalf("Error generating CV: %v\n%s", err, compileErr.Output)
+		}
 		log.Fatalf("Error generating CV: %v", err)
 	}
 }
+
+// checkTypst stops when Typst cannot compile the templates as configured. It
+// runs only before compiling, since the text exports do without Typst.
+func checkTypst(gen *generator.CVGenerator, packagesDir string, offline bool) {
+	// Typst would silently substitute a missing font
+	if err := gen.CheckFonts(); err != nil {
+		log.Fatalf("Error checking fonts: %v", err)
+	}
+
+	// Without the packages, Typst downloads them on every cold compilation
+	missing, err := gen.MissingPackages()
+	if err != nil {
+		log.Fatalf("Error checking packages: %v", err)
+	}
+	if len(missing) > 0 {
+		if offline {
+			log.Fatalf("Typst packages missing from %s: %v (run with -vendor-packages)", packagesDir, missing)
+		}
+		log.Printf("Warning: Typst packages missing from %s will be downloaded: %v", packagesDir, missing)
+	}
+}
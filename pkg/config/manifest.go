@@ -0,0 +1,220 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/renderer"
+	"github.com/AlexTLDR/mycv.quest/pkg/schema"
+	"gopkg.in/yaml.v2"
+)
+
+// ManifestFile is the file that marks a directory under the templates root
+// as a template.
+const ManifestFile = "manifest.yaml"
+
+var templateKey = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// MaxPhotoSize bounds photo_size. Larger photos only slow compiles down.
+const MaxPhotoSize = 2000
+
+// Manifest describes a template. Dir, ExamplePDF and Thumbnail are relative to
+// the manifest's directory; Entry and Assets are relative to Dir.
+type Manifest struct {
+	// Key defaults to the name of the manifest's directory.
+	Key         string `yaml:"key"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	// Renderer names a Go renderer. Without one the CV is written to
+	// cv.json for the entry file to read.
+	Renderer   string         `yaml:"renderer"`
+	Dir        string         `yaml:"dir"`
+	Entry      string         `yaml:"entry"`
+	Assets     []string       `yaml:"assets"`
+	NeedsPhoto bool           `yaml:"needs_photo"`
+	ExamplePDF string         `yaml:"example_pdf"`
+	Thumbnail  string         `yaml:"thumbnail"`
+	Fields     []schema.Field `yaml:"fields"`
+	// PhotoSize is the side in pixels uploaded photos are scaled down to.
+	// Zero uses the default.
+	PhotoSize int `yaml:"photo_size"`
+}
+
+// LoadManifests registers every template directory under root that has a
+// manifest. Directories without one are skipped.
+func (c *Config) LoadManifests(root string) error {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return fmt.Errorf("failed to read templates directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		manifestDir := filepath.Join(root, entry.Name())
+		manifest, err := ReadManifest(filepath.Join(manifestDir, ManifestFile))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		key, template, err := manifest.template(manifestDir)
+		if err != nil {
+			return fmt.Errorf("invalid manifest in %s: %w", manifestDir, err)
+		}
+		if err := c.add(key, template); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadManifest parses a manifest file. Unknown keys are rejected.
+func ReadManifest(path string) (*Manifest, error) {
+	// #nosec G304 - path is built from the configured templates directory
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest Manifest
+	if err := yaml.UnmarshalStrict(content, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &manifest, nil
+}
+
+// template validates the manifest and resolves it against the renderer it
+// names. It returns the template's key.
+func (m *Manifest) template(manifestDir string) (string, Template, error) {
+	key := m.Key
+	if key == "" {
+		key = filepath.Base(manifestDir)
+	}
+	if !templateKey.MatchString(key) {
+		return "", Template{}, fmt.Errorf("invalid key %q", key)
+	}
+
+	var r renderer.TemplateRenderer
+	if m.Renderer != "" {
+		builtin, exists := renderer.Builtin(m.Renderer)
+		if !exists {
+			return "", Template{}, fmt.Errorf("unknown renderer %q", m.Renderer)
+		}
+		r = builtin
+	}
+
+	var defaults renderer.Metadata
+	if r != nil {
+		defaults = r.Metadata()
+	}
+
+	template := Template{
+		Name:        firstNonEmpty(m.Name, defaults.Name),
+		Description: firstNonEmpty(m.Description, defaults.Description),
+		InputFile:   firstNonEmpty(m.Entry, defaults.EntryFile),
+		NeedsPhoto:  m.NeedsPhoto || defaults.NeedsPhoto,
+		PhotoSize:   m.PhotoSize,
+		Assets:      m.Assets,
+		Fields:      m.Fields,
+	}
+	if template.Name == "" {
+		return "", Template{}, fmt.Errorf("name is required")
+	}
+	if template.InputFile == "" {
+		return "", Template{}, fmt.Errorf("entry is required")
+	}
+	if template.PhotoSize < 0 || template.PhotoSize > MaxPhotoSize {
+		return "", Template{}, fmt.Errorf("photo_size must be between 1 and %d", MaxPhotoSize)
+	}
+
+	if r == nil {
+		r = renderer.Data{TemplateKey: key, Meta: renderer.Metadata{
+			Name:        template.Name,
+			Description: template.Description,
+			EntryFile:   template.InputFile,
+			NeedsPhoto:  template.NeedsPhoto,
+		}}
+	}
+	template.Renderer = r
+	if template.Assets == nil {
+		template.Assets = r.SourceFiles()
+	}
+
+	var err error
+	if template.Dir, err = resolve(manifestDir, m.Dir, "dir"); err != nil {
+		return "", Template{}, err
+	}
+	if info, err := os.Stat(template.Dir); err != nil || !info.IsDir() {
+		return "", Template{}, fmt.Errorf("dir %q is not a directory", m.Dir)
+	}
+
+	if !strings.HasSuffix(template.InputFile, ".typ") {
+		return "", Template{}, fmt.Errorf("entry %q must be a .typ file", template.InputFile)
+	}
+	if _, err := existing(template.Dir, template.InputFile, "entry"); err != nil {
+		return "", Template{}, err
+	}
+	for _, asset := range template.Assets {
+		if _, err := existing(template.Dir, asset, "asset"); err != nil {
+			return "", Template{}, err
+		}
+	}
+
+	if m.ExamplePDF != "" {
+		if template.ExamplePDF, err = existing(manifestDir, m.ExamplePDF, "example_pdf"); err != nil {
+			return "", Template{}, err
+		}
+	}
+	if m.Thumbnail != "" {
+		if template.Thumbnail, err = existing(manifestDir, m.Thumbnail, "thumbnail"); err != nil {
+			return "", Template{}, err
+		}
+	}
+
+	if err := schema.Validate(template.Fields); err != nil {
+		return "", Template{}, fmt.Errorf("invalid fields: %w", err)
+	}
+	template.Fonts = templateFonts(defaults.Font, template.Fields)
+
+	return key, template, nil
+}
+
+// resolve joins a manifest path to base, rejecting paths that escape it.
+func resolve(base, path, name string) (string, error) {
+	if path != "" && !filepath.IsLocal(path) {
+		return "", fmt.Errorf("%s %q must be a relative path inside the template", name, path)
+	}
+	return filepath.Join(base, path), nil
+}
+
+// existing is like resolve but also requires the path to exist.
+func existing(base, path, name string) (string, error) {
+	resolved, err := resolve(base, path, name)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(resolved); err != nil {
+		return "", fmt.Errorf("%s %q not found", name, path)
+	}
+	return resolved, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,133 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/config"
+	"github.com/AlexTLDR/mycv.quest/pkg/renderer"
+)
+
+// writeTemplate creates root/name with a manifest and the given files.
+func writeTemplate(t *testing.T, root, name, manifest string, files ...string) {
+	t.Helper()
+	dir := filepath.Join(root, name)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		t.Fatalf("Failed to create template dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, config.ManifestFile), []byte(manifest), 0o600); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+	for _, file := range files {
+		path := filepath.Join(dir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatalf("Failed to create dir for %s: %v", file, err)
+		}
+		if err := os.WriteFile(path, []byte("// "+file), 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %v", file, err)
+		}
+	}
+}
+
+func TestLoadManifestsDataTemplate(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+
+	writeTemplate(t, root, "minimal", `
+name: Minimal
+description: A template dropped in without a Go renderer
+entry: main.typ
+assets: [fonts/minimal.otf]
+thumbnail: thumb.png
+fields:
+  - name: headline
+    label: Headline
+    type: text
+    bind: person.title
+`, "main.typ", "fonts/minimal.otf", "thumb.png")
+
+	// Directories without a manifest are not templates
+	if err := os.MkdirAll(filepath.Join(root, "shared"), 0o750); err != nil {
+		t.Fatalf("Failed to create dir: %v", err)
+	}
+
+	cfg, err := config.NewConfig(root)
+	if err != nil {
+		t.Fatalf("NewConfig failed: %v", err)
+	}
+	if len(cfg.Templates) != 1 {
+		t.Fatalf("Expected 1 template, got %d", len(cfg.Templates))
+	}
+
+	template, exists := cfg.GetTemplate("minimal")
+	if !exists {
+		t.Fatal("Template minimal not registered")
+	}
+	if _, ok := template.Renderer.(renderer.Data); !ok {
+		t.Errorf("Expected data renderer, got %T", template.Renderer)
+	}
+	if template.Dir != filepath.Join(root, "minimal") || template.InputFile != "main.typ" {
+		t.Errorf("Unexpected dir or entry: %s %s", template.Dir, template.InputFile)
+	}
+	if template.Thumbnail != filepath.Join(root, "minimal", "thumb.png") {
+		t.Errorf("Unexpected thumbnail path: %s", template.Thumbnail)
+	}
+	if len(template.Fields) != 1 || template.Fields[0].Name != "headline" {
+		t.Errorf("Unexpected fields: %+v", template.Fields)
+	}
+}
+
+func TestLoadManifestsInvalid(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		manifest string
+		files    []string
+		wantErr  string
+	}{
+		{"missing name", "entry: main.typ", []string{"main.typ"}, "name is required"},
+		{"unknown key", "name: X\nentry: main.typ\nentrypoint: main.typ", []string{"main.typ"}, "failed to parse"},
+		{"unknown renderer", "name: X\nrenderer: fancy\nentry: main.typ", []string{"main.typ"}, "unknown renderer"},
+		{"missing entry file", "name: X\nentry: main.typ", nil, `entry "main.typ" not found`},
+		{"non typst entry", "name: X\nentry: main.sh", []string{"main.sh"}, "must be a .typ file"},
+		{"asset outside template", "name: X\nentry: main.typ\nassets: [../secret]", []string{"main.typ"}, "relative path inside the template"},
+		{"invalid key", "key: Bad Key\nname: X\nentry: main.typ", []string{"main.typ"}, "invalid key"},
+		{"photo size too large", "name: X\nentry: main.typ\nphoto_size: 5000", []string{"main.typ"}, "photo_size must be between"},
+		{"invalid field", "name: X\nentry: main.typ\nfields:\n  - name: a\n    type: slider", []string{"main.typ"}, "unknown type"},
+		{"unknown binding", "name: X\nentry: main.typ\nfields:\n  - name: a\n    type: text\n    bind: person.age", []string{"main.typ"}, "binds unknown value"},
+		{"list binding scalar", "name: X\nentry: main.typ\nfields:\n  - name: a\n    type: list\n    bind: person.name", []string{"main.typ"}, "must bind a list"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			root := t.TempDir()
+			writeTemplate(t, root, "broken", tc.manifest, tc.files...)
+
+			_, err := config.NewConfig(root)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Expected error containing %q, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoadManifestsDuplicateKey(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	writeTemplate(t, root, "one", "key: same\nname: One\nentry: main.typ", "main.typ")
+	writeTemplate(t, root, "two", "key: same\nname: Two\nentry: main.typ", "main.typ")
+
+	if _, err := config.NewConfig(root); err == nil || !strings.Contains(err.Error(), "already registered") {
+		t.Errorf("Expected duplicate key error, got: %v", err)
+	}
+}
+
+func TestNewConfigNoTemplates(t *testing.T) {
+	t.Parallel()
+	if _, err := config.NewConfig(t.TempDir()); err == nil {
+		t.Error("Expected error for a directory without templates")
+	}
+}
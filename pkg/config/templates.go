@@ -5,46 +5,124 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/renderer"
+	"github.com/AlexTLDR/mycv.quest/pkg/schema"
 )
 
 type Template struct {
-	Name       string
-	Dir        string
-	InputFile  string
+	Name        string
+	Description string
+	Dir         string
+	InputFile   string
+	// Assets are copied from Dir into the work directory with InputFile.
+	Assets     []string
 	NeedsPhoto bool
+	// PhotoSize is the side in pixels uploaded photos are scaled down to,
+	// or zero for the default.
+	PhotoSize int
+	// ExamplePDF and Thumbnail are file paths, empty when not provided.
+	ExamplePDF string
+	Thumbnail  string
+	Fields     []schema.Field
+	Renderer   renderer.TemplateRenderer
+	// Fonts are the families the template may set: its default and the
+	// choices its form offers.
+	Fonts []string
 }
 
 type Config struct {
 	Templates map[string]Template
 	OutputDir string
+	// WorkDir is the root of the directories generations compile in.
+	WorkDir string
+	// PackageDir holds the Typst packages templates import, laid out as
+	// <namespace>/<name>/<version>.
+	PackageDir string
+	// FontDir holds the fonts Typst compiles with instead of system fonts.
+	FontDir string
 }
 
-func NewConfig() *Config {
-	templates := map[string]Template{
-		"vantage": {
-			Name:       "Vantage",
-			Dir:        "templates/vantage",
-			InputFile:  "example.typ",
-			NeedsPhoto: false,
-		},
-		"basic": {
-			Name:       "Basic Resume",
-			Dir:        "templates/basic/template",
-			InputFile:  "main.typ",
-			NeedsPhoto: false,
-		},
-		"modern": {
-			Name:       "Modern Resume",
-			Dir:        "templates/modern/template",
-			InputFile:  "main.typ",
-			NeedsPhoto: true,
-		},
+// NewConfig loads the templates described by the manifests under templatesDir.
+func NewConfig(templatesDir string) (*Config, error) {
+	cfg := &Config{
+		Templates:  make(map[string]Template),
+		OutputDir:  "output",
+		WorkDir:    "temp",
+		PackageDir: "packages",
+		FontDir:    "fonts",
+	}
+
+	if err := cfg.LoadManifests(templatesDir); err != nil {
+		return nil, err
+	}
+	if len(cfg.Templates) == 0 {
+		return nil, fmt.Errorf("no templates found in %s", templatesDir)
+	}
+
+	return cfg, nil
+}
+
+// Register adds a template whose sources live in dir, keyed by the
+// renderer's key.
+func (c *Config) Register(dir string, r renderer.TemplateRenderer) error {
+	meta := r.Metadata()
+	return c.add(r.Key(), Template{
+		Name:        meta.Name,
+		Description: meta.Description,
+		Dir:         dir,
+		InputFile:   meta.EntryFile,
+		Assets:      r.SourceFiles(),
+		NeedsPhoto:  meta.NeedsPhoto,
+		Renderer:    r,
+		Fonts:       templateFonts(meta.Font, nil),
+	})
+}
+
+// FontField is the binding of the form field that picks the font.
+const FontField = "theme.font"
+
+// templateFonts returns the default font followed by the options of the
+// fields that pick the font, without duplicates.
+func templateFonts(defaultFont string, fields []schema.Field) []string {
+	var fonts []string
+	if defaultFont != "" {
+		fonts = append(fonts, defaultFont)
+	}
+	for _, field := range fields {
+		if field.Type == schema.Section {
+			fonts = append(fonts, templateFonts("", field.Fields)...)
+			continue
+		}
+		if field.Bind != FontField {
+			continue
+		}
+		for _, option := range field.Options {
+			fonts = append(fonts, option.Value)
+		}
+		if field.Default != "" {
+			fonts = append(fonts, field.Default)
+		}
 	}
 
-	return &Config{
-		Templates: templates,
-		OutputDir: "output",
+	var unique []string
+	for _, font := range fonts {
+		if !slices.Contains(unique, font) {
+			unique = append(unique, font)
+		}
 	}
+	return unique
+}
+
+func (c *Config) add(key string, template Template) error {
+	if _, exists := c.Templates[key]; exists {
+		return fmt.Errorf("template '%s' is already registered", key)
+	}
+	if c.Templates == nil {
+		c.Templates = make(map[string]Template)
+	}
+	c.Templates[key] = template
+	return nil
 }
 
 func (c *Config) GetTemplate(key string) (Template, bool) {
@@ -87,8 +165,7 @@ func validatePath(path, expectedPrefix string) error {
 }
 
 func ValidateTemplateArgs(template Template, outputFile string) error {
-	allowedInputFiles := []string{"example.typ", "main.typ"}
-	if !slices.Contains(allowedInputFiles, template.InputFile) {
+	if !strings.HasSuffix(template.InputFile, ".typ") || !filepath.IsLocal(template.InputFile) {
 		return fmt.Errorf("invalid input file: %s", template.InputFile)
 	}
 
@@ -97,8 +174,10 @@ func ValidateTemplateArgs(template Template, outputFile string) error {
 		return fmt.Errorf("invalid characters in file paths")
 	}
 
-	if !strings.HasSuffix(outputFile, ".pdf") {
-		return fmt.Errorf("output file must have .pdf extension")
+	switch filepath.Ext(outputFile) {
+	case ".pdf", ".png", ".svg", ".zip":
+	default:
+		return fmt.Errorf("output file must have .pdf, .png, .svg or .zip extension")
 	}
 
 	if err := validatePath(outputFile, ""); err != nil {
@@ -0,0 +1,51 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/config"
+	"github.com/AlexTLDR/mycv.quest/pkg/renderer"
+)
+
+func TestNewConfigRegistersTemplates(t *testing.T) {
+	t.Parallel()
+	cfg, err := config.NewConfig("../../templates")
+	if err != nil {
+		t.Fatalf("NewConfig failed: %v", err)
+	}
+
+	for _, key := range []string{"basic", "modern", "vantage"} {
+		template, exists := cfg.GetTemplate(key)
+		if !exists {
+			t.Fatalf("Template %s not registered", key)
+		}
+		if template.Renderer == nil || template.Renderer.Key() != key {
+			t.Errorf("Template %s has wrong renderer", key)
+		}
+		if template.InputFile != template.Renderer.Metadata().EntryFile {
+			t.Errorf("Template %s input file %q does not match renderer entry file", key, template.InputFile)
+		}
+		if len(template.Fonts) < 2 || template.Fonts[0] != template.Renderer.Metadata().Font {
+			t.Errorf("Template %s should offer a choice of fonts starting with its default, got %v", key, template.Fonts)
+		}
+		if template.Description == "" || template.ExamplePDF == "" || template.Thumbnail == "" {
+			t.Errorf("Template %s is missing manifest metadata: %+v", key, template)
+		}
+	}
+
+	if modern, _ := cfg.GetTemplate("modern"); !modern.NeedsPhoto {
+		t.Error("Modern template should need a photo")
+	}
+}
+
+func TestRegisterDuplicate(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{Templates: map[string]config.Template{}}
+
+	if err := cfg.Register("templates/basic/template", renderer.Basic{}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := cfg.Register("elsewhere", renderer.Basic{}); err == nil {
+		t.Error("Expected error when registering a duplicate key")
+	}
+}
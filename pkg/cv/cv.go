@@ -0,0 +1,214 @@
+// Package cv defines the template-agnostic CV data model. Forms and imports
+// decode into a CV once, and every template renders from it.
+package cv
+
+import (
+	"strings"
+)
+
+// Skill categories used by the bundled templates. Skills without a category
+// are rendered in each template's generic skills section.
+const (
+	CategoryProgramming  = "Programming Languages"
+	CategoryTechnologies = "Technologies"
+	CategoryExpertise    = "Technical Expertise"
+	CategoryMethodology  = "Methodology"
+	CategoryTools        = "Tools"
+)
+
+type CV struct {
+	Person       Person        `json:"person"`
+	Contact      Contact       `json:"contact"`
+	Experience   []Experience  `json:"experience,omitempty"`
+	Education    []Education   `json:"education,omitempty"`
+	Projects     []Project     `json:"projects,omitempty"`
+	Certificates []Certificate `json:"certificates,omitempty"`
+	Skills       []Skill       `json:"skills,omitempty"`
+	Languages    []Language    `json:"languages,omitempty"`
+	Achievements []Achievement `json:"achievements,omitempty"`
+	Interests    []string      `json:"interests,omitempty"`
+	Theme        Theme         `json:"theme"`
+	Layout       Layout        `json:"layout"`
+}
+
+type Person struct {
+	Name      string `json:"name,omitempty"`
+	Title     string `json:"title,omitempty"`
+	Position  string `json:"position,omitempty"`
+	Summary   string `json:"summary,omitempty"`
+	Objective string `json:"objective,omitempty"`
+}
+
+type Contact struct {
+	Email    string `json:"email,omitempty"`
+	Phone    string `json:"phone,omitempty"`
+	Location string `json:"location,omitempty"`
+	LinkedIn Link   `json:"linkedin"`
+	GitHub   Link   `json:"github"`
+	Website  Link   `json:"website"`
+}
+
+// Link is a URL with an optional display label. URL may be a bare handle or
+// host without a scheme; renderers normalize it for their template.
+type Link struct {
+	URL   string `json:"url,omitempty"`
+	Label string `json:"label,omitempty"`
+}
+
+type Experience struct {
+	Title              string `json:"title,omitempty"`
+	Company            string `json:"company,omitempty"`
+	CompanyURL         string `json:"company_url,omitempty"`
+	CompanyDescription string `json:"company_description,omitempty"`
+	Product            string `json:"product,omitempty"`
+	ProductURL         string `json:"product_url,omitempty"`
+	Location           string `json:"location,omitempty"`
+	StartDate          Date   `json:"start_date,omitzero"`
+	EndDate            Date   `json:"end_date,omitzero"`
+	Description        string `json:"description,omitempty"`
+}
+
+type Education struct {
+	Institution    string `json:"institution,omitempty"`
+	InstitutionURL string `json:"institution_url,omitempty"`
+	Location       string `json:"location,omitempty"`
+	Degree         string `json:"degree,omitempty"`
+	Major          string `json:"major,omitempty"`
+	Track          string `json:"track,omitempty"`
+	GPA            string `json:"gpa,omitempty"`
+	StartDate      Date   `json:"start_date,omitzero"`
+	EndDate        Date   `json:"end_date,omitzero"`
+	Description    string `json:"description,omitempty"`
+}
+
+type Project struct {
+	Name        string `json:"name,omitempty"`
+	Role        string `json:"role,omitempty"`
+	URL         string `json:"url,omitempty"`
+	StartDate   Date   `json:"start_date,omitzero"`
+	EndDate     Date   `json:"end_date,omitzero"`
+	Description string `json:"description,omitempty"`
+}
+
+type Certificate struct {
+	Name      string `json:"name,omitempty"`
+	Issuer    string `json:"issuer,omitempty"`
+	URL       string `json:"url,omitempty"`
+	StartDate Date   `json:"start_date,omitzero"`
+	EndDate   Date   `json:"end_date,omitzero"`
+}
+
+// Skill is a single skill. Level is 1-5, or 0 when the source had no level.
+type Skill struct {
+	Name     string `json:"name"`
+	Category string `json:"category,omitempty"`
+	Level    int    `json:"level,omitempty"`
+}
+
+type Language struct {
+	Name    string `json:"name"`
+	Fluency string `json:"fluency,omitempty"`
+}
+
+type Achievement struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// Paper sizes, named as Typst names them.
+const (
+	PaperA4       = "a4"
+	PaperUSLetter = "us-letter"
+	PaperUSLegal  = "us-legal"
+)
+
+// Margin presets.
+const (
+	MarginsNarrow = "narrow"
+	MarginsNormal = "normal"
+	MarginsWide   = "wide"
+)
+
+// Layout is the page setup of a CV. Empty values leave the template's own.
+type Layout struct {
+	Paper   string `json:"paper,omitempty"`
+	Margins string `json:"margins,omitempty"`
+	// Lang is an ISO 639-1 code and Region an ISO 3166-1 alpha-2 code. They
+	// select hyphenation patterns and the formatting of dates.
+	Lang   string `json:"lang,omitempty"`
+	Region string `json:"region,omitempty"`
+	// DateFormat is one of DateFormats.
+	DateFormat string `json:"date_format,omitempty"`
+}
+
+type Theme struct {
+	AccentColor string `json:"accent_color,omitempty"`
+	// Font is the family of the body text. Empty leaves the template's own.
+	Font string `json:"font,omitempty"`
+	// PhotoFocus is the part of the photo kept when it is cropped square, as
+	// read by photo.ParseFocus. Empty keeps the centre.
+	PhotoFocus string `json:"photo_focus,omitempty"`
+}
+
+// SkillNames returns the names of the skills in the given categories, in
+// order. An empty category matches uncategorized skills.
+func (c *CV) SkillNames(categories ...string) []string {
+	var names []string
+	for _, skill := range c.Skills {
+		for _, category := range categories {
+			if skill.Category == category {
+				names = append(names, skill.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// String renders the language the way users type it, e.g. "German (native)".
+func (l Language) String() string {
+	if l.Fluency == "" {
+		return l.Name
+	}
+	return l.Name + " (" + l.Fluency + ")"
+}
+
+// ParseLanguage is the inverse of Language.String.
+func ParseLanguage(s string) Language {
+	s = strings.TrimSpace(s)
+	if open := strings.LastIndex(s, " ("); open > 0 && strings.HasSuffix(s, ")") {
+		return Language{Name: s[:open], Fluency: s[open+2 : len(s)-1]}
+	}
+	return Language{Name: s}
+}
+
+// Lines splits a free-text description into its non-empty lines, trimming
+// whitespace and any leading bullet marker.
+func Lines(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		for _, marker := range []string{"- ", "• "} {
+			if strings.HasPrefix(line, marker) {
+				line = strings.TrimSpace(line[len(marker):])
+				break
+			}
+		}
+		if line != "" && line != "-" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// SplitList splits a comma-separated list, dropping empty items.
+func SplitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,72 @@
+package cv_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+)
+
+func TestLines(t *testing.T) {
+	t.Parallel()
+	text := "\n  - Built a thing\n\n• Shipped it\nPlain line\n-5% latency\n  -  \n"
+
+	got := cv.Lines(text)
+	want := []string{"Built a thing", "Shipped it", "Plain line", "-5% latency"}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("Lines() = %q, want %q", got, want)
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	t.Parallel()
+	got := cv.SplitList("Go, React,   Node.js  , ,Docker")
+	want := []string{"Go", "React", "Node.js", "Docker"}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("SplitList() = %q, want %q", got, want)
+	}
+}
+
+func TestParseLanguage(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		input string
+		want  cv.Language
+	}{
+		{"German (native)", cv.Language{Name: "German", Fluency: "native"}},
+		{"English", cv.Language{Name: "English"}},
+		{"  Spanish (B2) ", cv.Language{Name: "Spanish", Fluency: "B2"}},
+	}
+
+	for _, tc := range testCases {
+		got := cv.ParseLanguage(tc.input)
+		if got != tc.want {
+			t.Errorf("ParseLanguage(%q) = %+v, want %+v", tc.input, got, tc.want)
+		}
+		if roundTrip := cv.ParseLanguage(got.String()); roundTrip != got {
+			t.Errorf("ParseLanguage(%q.String()) = %+v, want %+v", got, roundTrip, got)
+		}
+	}
+}
+
+func TestSkillNames(t *testing.T) {
+	t.Parallel()
+	data := &cv.CV{
+		Skills: []cv.Skill{
+			{Name: "Go", Category: cv.CategoryProgramming},
+			{Name: "Docker", Category: cv.CategoryTools},
+			{Name: "Leadership"},
+			{Name: "Rust", Category: cv.CategoryProgramming},
+		},
+	}
+
+	if got := data.SkillNames(cv.CategoryProgramming); !slices.Equal(got, []string{"Go", "Rust"}) {
+		t.Errorf("SkillNames(programming) = %q", got)
+	}
+
+	if got := data.SkillNames("", cv.CategoryTools); !slices.Equal(got, []string{"Docker", "Leadership"}) {
+		t.Errorf("SkillNames(uncategorized, tools) = %q", got)
+	}
+}
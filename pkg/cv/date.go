@@ -0,0 +1,356 @@
+package cv
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Date formats a template may display dates in. Dates are shown to the
+// precision they were entered with, so a year stays a year in every format.
+const (
+	// DateShort shows "Aug 2023", with the day as the language writes it.
+	DateShort = "short"
+	// DateLong shows "August 2023", with the day as the language writes it.
+	DateLong = "long"
+	// DateNumeric shows "08/2023" or "15/08/2023".
+	DateNumeric = "numeric"
+	// DateISO shows "2023-08" or "2023-08-15".
+	DateISO = "iso"
+	// DateYear shows only the year.
+	DateYear = "year"
+)
+
+// DateFormats lists the date formats in the order forms offer them.
+var DateFormats = []string{DateShort, DateLong, DateNumeric, DateISO, DateYear}
+
+// presentWords are accepted for an ongoing end date.
+var presentWords = []string{"present", "current", "now", "today", "ongoing"}
+
+// Date is a date as precise as the user gave it: a year, a month or a day.
+// Month and Day are 0 when unknown. A Present date marks an ongoing entry.
+type Date struct {
+	Year    int
+	Month   int
+	Day     int
+	Present bool
+}
+
+// Present is the end date of an ongoing entry.
+var Present = Date{Present: true}
+
+// ParseDate reads the date formats users type into the forms: "2023",
+// "Aug 2023", "August 2023", "2023 Mar.", "08/2023", "2023-08",
+// "2023-08-15", "15.08.2023", "15 Aug 2023" and "Present". An empty string
+// is the zero date.
+func ParseDate(value string) (Date, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return Date{}, nil
+	}
+	if slices.Contains(presentWords, value) {
+		return Present, nil
+	}
+
+	parts := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ' ' || r == '/' || r == '-' || r == '.' || r == ','
+	})
+	var date Date
+	var ok bool
+	switch len(parts) {
+	case 1:
+		date.Year, ok = parseYear(parts[0])
+	case 2:
+		// Either order: "08/2023", "2023-08", "Aug 2023", "2023 Mar."
+		date, ok = parseMonthYear(parts[0], parts[1])
+		if !ok {
+			date, ok = parseMonthYear(parts[1], parts[0])
+		}
+	case 3:
+		date, ok = parseFullDate(parts)
+	}
+	if !ok {
+		return Date{}, fmt.Errorf("invalid date %q", value)
+	}
+	return date, nil
+}
+
+// MustParseDate is like ParseDate but panics on invalid dates. It is meant
+// for dates written in code.
+func MustParseDate(value string) Date {
+	date, err := ParseDate(value)
+	if err != nil {
+		panic(err)
+	}
+	return date
+}
+
+func parseMonthYear(month, year string) (Date, bool) {
+	m, monthOK := parseMonth(month)
+	y, yearOK := parseYear(year)
+	return Date{Year: y, Month: m}, monthOK && yearOK
+}
+
+// parseFullDate reads year-month-day, day-month-year and month-day-year, the
+// last only with a month name since "08/15/2023" and "15/08/2023" are
+// ambiguous otherwise.
+func parseFullDate(parts []string) (Date, bool) {
+	var date Date
+	var ok bool
+	switch {
+	case len(parts[0]) == 4:
+		date.Year, ok = parseYear(parts[0])
+		date.Month, date.Day = parseMonthDay(parts[1], parts[2])
+	case len(parts[2]) == 4:
+		date.Year, ok = parseYear(parts[2])
+		date.Month, date.Day = parseMonthDay(parts[1], parts[0])
+		if date.Month == 0 && !isNumber(parts[0]) {
+			date.Month, date.Day = parseMonthDay(parts[0], parts[1])
+		}
+	}
+	return date, ok && date.Month != 0 && date.Day != 0
+}
+
+// parseMonthDay returns 0, 0 unless month and day form a valid date in a leap
+// year.
+func parseMonthDay(month, day string) (int, int) {
+	m, ok := parseMonth(month)
+	d, err := strconv.Atoi(day)
+	if !ok || err != nil || len(day) > 2 || d < 1 || d > daysIn(m) {
+		return 0, 0
+	}
+	return m, d
+}
+
+func daysIn(month int) int {
+	switch month {
+	case 2:
+		return 29
+	case 4, 6, 9, 11:
+		return 30
+	default:
+		return 31
+	}
+}
+
+func parseYear(value string) (int, bool) {
+	year, err := strconv.Atoi(value)
+	return year, err == nil && len(value) == 4
+}
+
+func parseMonth(value string) (int, bool) {
+	if month, err := strconv.Atoi(value); err == nil {
+		return month, len(value) <= 2 && month >= 1 && month <= 12
+	}
+	if len(value) < 3 {
+		return 0, false
+	}
+	// English names may be abbreviated anywhere after three letters
+	for month, name := range monthNames["en"].short {
+		if strings.ToLower(name) == value[:3] {
+			return month + 1, true
+		}
+	}
+	for _, names := range monthNames {
+		for month := range names.short {
+			if value == strings.ToLower(strings.TrimSuffix(names.short[month], ".")) || value == strings.ToLower(names.long[month]) {
+				return month + 1, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func isNumber(value string) bool {
+	_, err := strconv.Atoi(value)
+	return err == nil
+}
+
+// IsZero reports whether the date is empty.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// String returns the date in ISO 8601 form at its precision, or "present".
+func (d Date) String() string {
+	switch {
+	case d.Present:
+		return presentWords[0]
+	case d.IsZero():
+		return ""
+	case d.Month == 0:
+		return fmt.Sprintf("%04d", d.Year)
+	case d.Day == 0:
+		return fmt.Sprintf("%04d-%02d", d.Year, d.Month)
+	default:
+		return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+	}
+}
+
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Date) UnmarshalText(text []byte) error {
+	date, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = date
+	return nil
+}
+
+// Compare orders dates by time. Present is later than every date and the
+// zero date earlier. A date known only to the year or month sorts before the
+// more precise dates within it.
+func (d Date) Compare(other Date) int {
+	if d.Present || other.Present {
+		return compareBool(d.Present, other.Present)
+	}
+	return cmp.Or(cmp.Compare(d.Year, other.Year), cmp.Compare(d.Month, other.Month), cmp.Compare(d.Day, other.Day))
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// Format returns the date for display in format and language lang, an ISO
+// 639-1 code. Unknown formats fall back to DateShort and unknown languages to
+// English.
+func (d Date) Format(format, lang string) string {
+	names, exists := monthNames[lang]
+	if !exists {
+		names = monthNames["en"]
+	}
+	switch {
+	case d.Present:
+		return names.present
+	case d.IsZero():
+		return ""
+	case d.Month == 0 || format == DateYear:
+		return strconv.Itoa(d.Year)
+	}
+
+	switch format {
+	case DateISO:
+		return d.String()
+	case DateNumeric:
+		if d.Day == 0 {
+			return fmt.Sprintf("%02d/%d", d.Month, d.Year)
+		}
+		return fmt.Sprintf("%02d/%02d/%d", d.Day, d.Month, d.Year)
+	case DateLong:
+		return names.join(d, names.long[d.Month-1])
+	default:
+		return names.join(d, names.short[d.Month-1])
+	}
+}
+
+// localeNames are the words dates are written with in one language.
+type localeNames struct {
+	short   [12]string
+	long    [12]string
+	present string
+	// monthFirst writes full dates as "Aug 15, 2023" rather than "15 Aug 2023".
+	monthFirst bool
+	// dayDot writes the day as "15." as German does.
+	dayDot bool
+}
+
+func (n localeNames) join(d Date, month string) string {
+	switch {
+	case d.Day == 0:
+		return month + " " + strconv.Itoa(d.Year)
+	case n.monthFirst:
+		return fmt.Sprintf("%s %d, %d", month, d.Day, d.Year)
+	case n.dayDot:
+		return fmt.Sprintf("%d. %s %d", d.Day, month, d.Year)
+	default:
+		return fmt.Sprintf("%d %s %d", d.Day, month, d.Year)
+	}
+}
+
+// monthNames holds the languages the forms offer.
+var monthNames = map[string]localeNames{
+	"en": {
+		short:      [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		long:       [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		present:    "Present",
+		monthFirst: true,
+	},
+	"de": {
+		short:   [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		long:    [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		present: "heute",
+		dayDot:  true,
+	},
+	"fr": {
+		short:   [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		long:    [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		present: "aujourd’hui",
+	},
+	"es": {
+		short:   [12]string{"ene.", "feb.", "mar.", "abr.", "may.", "jun.", "jul.", "ago.", "sept.", "oct.", "nov.", "dic."},
+		long:    [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		present: "actualidad",
+	},
+	"it": {
+		short:   [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		long:    [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		present: "presente",
+	},
+	"nl": {
+		short:   [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		long:    [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		present: "heden",
+	},
+	"pt": {
+		short:   [12]string{"jan.", "fev.", "mar.", "abr.", "mai.", "jun.", "jul.", "ago.", "set.", "out.", "nov.", "dez."},
+		long:    [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		present: "presente",
+	},
+	"ro": {
+		short:   [12]string{"ian.", "feb.", "mar.", "apr.", "mai", "iun.", "iul.", "aug.", "sept.", "oct.", "nov.", "dec."},
+		long:    [12]string{"ianuarie", "februarie", "martie", "aprilie", "mai", "iunie", "iulie", "august", "septembrie", "octombrie", "noiembrie", "decembrie"},
+		present: "prezent",
+	},
+}
+
+// SortedByDate returns a copy of the CV with experience, education, projects
+// and certificates ordered newest first: by end date, ongoing entries first,
+// then by start date. Entries without dates keep their order after the rest.
+func (c *CV) SortedByDate() *CV {
+	sorted := *c
+	sorted.Experience = sortByDate(c.Experience, func(e Experience) (Date, Date) { return e.StartDate, e.EndDate })
+	sorted.Education = sortByDate(c.Education, func(e Education) (Date, Date) { return e.StartDate, e.EndDate })
+	sorted.Projects = sortByDate(c.Projects, func(p Project) (Date, Date) { return p.StartDate, p.EndDate })
+	sorted.Certificates = sortByDate(c.Certificates, func(c Certificate) (Date, Date) { return c.StartDate, c.EndDate })
+	return &sorted
+}
+
+func sortByDate[E any](entries []E, dates func(E) (Date, Date)) []E {
+	if entries == nil {
+		return nil
+	}
+	sorted := slices.Clone(entries)
+	slices.SortStableFunc(sorted, func(a, b E) int {
+		aStart, aEnd := dates(a)
+		bStart, bEnd := dates(b)
+		// An entry without an end date ended when it started
+		aEnd, bEnd = cmp.Or(aEnd, aStart), cmp.Or(bEnd, bStart)
+		if aEnd.IsZero() || bEnd.IsZero() {
+			return compareBool(aEnd.IsZero(), bEnd.IsZero())
+		}
+		return cmp.Or(bEnd.Compare(aEnd), bStart.Compare(aStart))
+	})
+	return sorted
+}
@@ -0,0 +1,124 @@
+package cv_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+)
+
+func TestParseDate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		value string
+		want  cv.Date
+	}{
+		{"", cv.Date{}},
+		{"2023", cv.Date{Year: 2023}},
+		{"Aug 2023", cv.Date{Year: 2023, Month: 8}},
+		{"August 2023", cv.Date{Year: 2023, Month: 8}},
+		{"2023 Mar.", cv.Date{Year: 2023, Month: 3}},
+		{"08/2023", cv.Date{Year: 2023, Month: 8}},
+		{"2023-08", cv.Date{Year: 2023, Month: 8}},
+		{"März 2023", cv.Date{Year: 2023, Month: 3}},
+		{"juillet 2023", cv.Date{Year: 2023, Month: 7}},
+		{"2023-08-15", cv.Date{Year: 2023, Month: 8, Day: 15}},
+		{"15.08.2023", cv.Date{Year: 2023, Month: 8, Day: 15}},
+		{"15 Aug 2023", cv.Date{Year: 2023, Month: 8, Day: 15}},
+		{"Aug 15, 2023", cv.Date{Year: 2023, Month: 8, Day: 15}},
+		{" Present ", cv.Present},
+		{"ongoing", cv.Present},
+	}
+	for _, test := range tests {
+		got, err := cv.ParseDate(test.value)
+		if err != nil || got != test.want {
+			t.Errorf("ParseDate(%q) = %+v, %v, want %+v", test.value, got, err, test.want)
+		}
+	}
+
+	for _, value := range []string{"soon", "23", "13/2023", "2023-02-30", "08/15/2023", "Aug 2023 extra words"} {
+		if _, err := cv.ParseDate(value); err == nil {
+			t.Errorf("ParseDate(%q) should fail", value)
+		}
+	}
+}
+
+func TestDateFormat(t *testing.T) {
+	t.Parallel()
+	month := cv.MustParseDate("2023-08")
+	day := cv.MustParseDate("2023-08-05")
+	tests := []struct {
+		date         cv.Date
+		format, lang string
+		want         string
+	}{
+		{month, cv.DateShort, "en", "Aug 2023"},
+		{month, cv.DateLong, "de", "August 2023"},
+		{cv.MustParseDate("2023-03"), cv.DateShort, "de", "März 2023"},
+		{month, cv.DateLong, "fr", "août 2023"},
+		{month, cv.DateNumeric, "en", "08/2023"},
+		{month, cv.DateISO, "en", "2023-08"},
+		{month, cv.DateYear, "en", "2023"},
+		{day, cv.DateShort, "en", "Aug 5, 2023"},
+		{day, cv.DateLong, "de", "5. August 2023"},
+		{day, cv.DateLong, "es", "5 agosto 2023"},
+		{day, cv.DateNumeric, "en", "05/08/2023"},
+		{cv.MustParseDate("2023"), cv.DateLong, "en", "2023"},
+		{cv.Present, cv.DateShort, "de", "heute"},
+		{cv.Present, cv.DateShort, "xx", "Present"},
+		{cv.Date{}, cv.DateShort, "en", ""},
+	}
+	for _, test := range tests {
+		if got := test.date.Format(test.format, test.lang); got != test.want {
+			t.Errorf("%v.Format(%q, %q) = %q, want %q", test.date, test.format, test.lang, got, test.want)
+		}
+	}
+}
+
+func TestDateJSON(t *testing.T) {
+	t.Parallel()
+	entry := cv.Experience{Title: "Engineer", StartDate: cv.MustParseDate("Aug 2023"), EndDate: cv.Present}
+	encoded, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(encoded), `"start_date":"2023-08","end_date":"present"`) {
+		t.Errorf("Unexpected JSON: %s", encoded)
+	}
+
+	var decoded cv.Experience
+	if err := json.Unmarshal(encoded, &decoded); err != nil || decoded != entry {
+		t.Errorf("Round trip gave %+v, %v", decoded, err)
+	}
+
+	// Empty dates are left out
+	if encoded, _ := json.Marshal(cv.Project{Name: "x"}); strings.Contains(string(encoded), "date") {
+		t.Errorf("Unexpected dates in %s", encoded)
+	}
+}
+
+func TestSortedByDate(t *testing.T) {
+	t.Parallel()
+	data := &cv.CV{Experience: []cv.Experience{
+		{Title: "undated"},
+		{Title: "oldest", StartDate: cv.MustParseDate("2015"), EndDate: cv.MustParseDate("2017")},
+		{Title: "current", StartDate: cv.MustParseDate("2021-03"), EndDate: cv.Present},
+		{Title: "recent", StartDate: cv.MustParseDate("2018-01"), EndDate: cv.MustParseDate("2021-02")},
+		{Title: "current, started later", StartDate: cv.MustParseDate("2022-06"), EndDate: cv.Present},
+		{Title: "start only", StartDate: cv.MustParseDate("2019")},
+	}}
+
+	sorted := data.SortedByDate()
+	var titles []string
+	for _, entry := range sorted.Experience {
+		titles = append(titles, entry.Title)
+	}
+	want := "current, started later|current|recent|start only|oldest|undated"
+	if got := strings.Join(titles, "|"); got != want {
+		t.Errorf("Sorted titles = %s, want %s", got, want)
+	}
+	if data.Experience[0].Title != "undated" {
+		t.Error("SortedByDate should not reorder the original CV")
+	}
+}
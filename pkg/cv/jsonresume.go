@@ -0,0 +1,441 @@
+package cv
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// JSONResume is the subset of the JSON Resume schema (https://jsonresume.org/schema)
+// that maps onto the CV model.
+type JSONResume struct {
+	Basics       JSONResumeBasics        `json:"basics"`
+	Work         []JSONResumeWork        `json:"work,omitempty"`
+	Education    []JSONResumeEducation   `json:"education,omitempty"`
+	Projects     []JSONResumeProject     `json:"projects,omitempty"`
+	Certificates []JSONResumeCertificate `json:"certificates,omitempty"`
+	Awards       []JSONResumeAward       `json:"awards,omitempty"`
+	Skills       []JSONResumeSkill       `json:"skills,omitempty"`
+	Languages    []JSONResumeLanguage    `json:"languages,omitempty"`
+	Interests    []JSONResumeInterest    `json:"interests,omitempty"`
+}
+
+type JSONResumeBasics struct {
+	Name     string              `json:"name,omitempty"`
+	Label    string              `json:"label,omitempty"`
+	Email    string              `json:"email,omitempty"`
+	Phone    string              `json:"phone,omitempty"`
+	URL      string              `json:"url,omitempty"`
+	Summary  string              `json:"summary,omitempty"`
+	Location JSONResumeLocation  `json:"location"`
+	Profiles []JSONResumeProfile `json:"profiles,omitempty"`
+}
+
+type JSONResumeLocation struct {
+	Address     string `json:"address,omitempty"`
+	PostalCode  string `json:"postalCode,omitempty"`
+	City        string `json:"city,omitempty"`
+	CountryCode string `json:"countryCode,omitempty"`
+	Region      string `json:"region,omitempty"`
+}
+
+type JSONResumeProfile struct {
+	Network  string `json:"network,omitempty"`
+	Username string `json:"username,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+type JSONResumeWork struct {
+	Name        string   `json:"name,omitempty"`
+	Position    string   `json:"position,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	Location    string   `json:"location,omitempty"`
+	Description string   `json:"description,omitempty"`
+	StartDate   string   `json:"startDate,omitempty"`
+	EndDate     string   `json:"endDate,omitempty"`
+	Summary     string   `json:"summary,omitempty"`
+	Highlights  []string `json:"highlights,omitempty"`
+}
+
+type JSONResumeEducation struct {
+	Institution string   `json:"institution,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	Area        string   `json:"area,omitempty"`
+	StudyType   string   `json:"studyType,omitempty"`
+	StartDate   string   `json:"startDate,omitempty"`
+	EndDate     string   `json:"endDate,omitempty"`
+	Score       string   `json:"score,omitempty"`
+	Courses     []string `json:"courses,omitempty"`
+}
+
+type JSONResumeProject struct {
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Highlights  []string `json:"highlights,omitempty"`
+	StartDate   string   `json:"startDate,omitempty"`
+	EndDate     string   `json:"endDate,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+}
+
+type JSONResumeCertificate struct {
+	Name   string `json:"name,omitempty"`
+	Date   string `json:"date,omitempty"`
+	Issuer string `json:"issuer,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+type JSONResumeAward struct {
+	Title   string `json:"title,omitempty"`
+	Date    string `json:"date,omitempty"`
+	Awarder string `json:"awarder,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
+
+type JSONResumeSkill struct {
+	Name     string   `json:"name,omitempty"`
+	Level    string   `json:"level,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+type JSONResumeLanguage struct {
+	Language string `json:"language,omitempty"`
+	Fluency  string `json:"fluency,omitempty"`
+}
+
+type JSONResumeInterest struct {
+	Name     string   `json:"name,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// skillLevels names the 1-5 levels, as offered by the vantage form.
+var skillLevels = []string{"Beginner", "Novice", "Intermediate", "Advanced", "Expert"}
+
+// ReadJSONResume decodes a JSON Resume document into a CV.
+func ReadJSONResume(r io.Reader) (*CV, error) {
+	var resume JSONResume
+	if err := json.NewDecoder(r).Decode(&resume); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON Resume: %w", err)
+	}
+	return FromJSONResume(&resume), nil
+}
+
+// WriteJSONResume encodes a CV as an indented JSON Resume document.
+func WriteJSONResume(w io.Writer, data *CV) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(ToJSONResume(data))
+}
+
+// FromJSONResume maps a JSON Resume document onto the CV model.
+func FromJSONResume(resume *JSONResume) *CV {
+	basics := resume.Basics
+	data := &CV{
+		Person: Person{
+			Name:    basics.Name,
+			Title:   basics.Label,
+			Summary: basics.Summary,
+		},
+		Contact: Contact{
+			Email:    basics.Email,
+			Phone:    basics.Phone,
+			Location: joinNonEmpty(", ", basics.Location.Address, basics.Location.City, basics.Location.Region, basics.Location.CountryCode),
+			Website:  Link{URL: basics.URL},
+		},
+	}
+
+	for _, profile := range basics.Profiles {
+		link := Link{URL: profile.URL, Label: profile.Username}
+		if link.URL == "" {
+			link.URL = profile.Username
+		}
+		switch strings.ToLower(profile.Network) {
+		case "linkedin":
+			data.Contact.LinkedIn = link
+		case "github":
+			data.Contact.GitHub = link
+		}
+	}
+
+	for _, work := range resume.Work {
+		data.Experience = append(data.Experience, Experience{
+			Title:              work.Position,
+			Company:            work.Name,
+			CompanyURL:         work.URL,
+			CompanyDescription: work.Description,
+			Location:           work.Location,
+			StartDate:          importDate(work.StartDate),
+			EndDate:            importEndDate(work.StartDate, work.EndDate),
+			Description:        joinDescription(work.Summary, work.Highlights),
+		})
+	}
+
+	for _, edu := range resume.Education {
+		data.Education = append(data.Education, Education{
+			Institution:    edu.Institution,
+			InstitutionURL: edu.URL,
+			Degree:         edu.StudyType,
+			Major:          edu.Area,
+			GPA:            edu.Score,
+			StartDate:      importDate(edu.StartDate),
+			EndDate:        importEndDate(edu.StartDate, edu.EndDate),
+			Description:    joinDescription("", edu.Courses),
+		})
+	}
+
+	for _, project := range resume.Projects {
+		data.Projects = append(data.Projects, Project{
+			Name:        project.Name,
+			Role:        strings.Join(project.Roles, ", "),
+			URL:         project.URL,
+			StartDate:   importDate(project.StartDate),
+			EndDate:     importDate(project.EndDate),
+			Description: joinDescription(project.Description, project.Highlights),
+		})
+	}
+
+	for _, certificate := range resume.Certificates {
+		data.Certificates = append(data.Certificates, Certificate{
+			Name:      certificate.Name,
+			Issuer:    certificate.Issuer,
+			URL:       certificate.URL,
+			StartDate: importDate(certificate.Date),
+		})
+	}
+
+	for _, award := range resume.Awards {
+		data.Achievements = append(data.Achievements, Achievement{
+			Name:        award.Title,
+			Description: award.Summary,
+		})
+	}
+
+	for _, group := range resume.Skills {
+		level := parseSkillLevel(group.Level)
+		if len(group.Keywords) == 0 {
+			category := ""
+			if level > 0 {
+				category = CategoryExpertise
+			}
+			data.Skills = append(data.Skills, Skill{Name: group.Name, Category: category, Level: level})
+			continue
+		}
+		category := knownCategory(group.Name)
+		for _, keyword := range group.Keywords {
+			data.Skills = append(data.Skills, Skill{Name: keyword, Category: category, Level: level})
+		}
+	}
+
+	for _, language := range resume.Languages {
+		data.Languages = append(data.Languages, Language{Name: language.Language, Fluency: language.Fluency})
+	}
+
+	for _, interest := range resume.Interests {
+		data.Interests = append(data.Interests, interest.Name)
+	}
+
+	return data
+}
+
+// ToJSONResume maps a CV onto a JSON Resume document.
+func ToJSONResume(data *CV) *JSONResume {
+	title := data.Person.Title
+	if title == "" {
+		title = data.Person.Position
+	}
+
+	resume := &JSONResume{
+		Basics: JSONResumeBasics{
+			Name:     data.Person.Name,
+			Label:    title,
+			Email:    data.Contact.Email,
+			Phone:    data.Contact.Phone,
+			URL:      data.Contact.Website.URL,
+			Summary:  data.Person.Summary,
+			Location: JSONResumeLocation{Address: data.Contact.Location},
+		},
+	}
+
+	for _, profile := range []struct {
+		network string
+		link    Link
+	}{{"LinkedIn", data.Contact.LinkedIn}, {"GitHub", data.Contact.GitHub}} {
+		if profile.link.URL != "" {
+			resume.Basics.Profiles = append(resume.Basics.Profiles, JSONResumeProfile{
+				Network:  profile.network,
+				Username: profile.link.Label,
+				URL:      profile.link.URL,
+			})
+		}
+	}
+
+	for _, work := range data.Experience {
+		resume.Work = append(resume.Work, JSONResumeWork{
+			Name:        work.Company,
+			Position:    work.Title,
+			URL:         work.CompanyURL,
+			Location:    work.Location,
+			Description: work.CompanyDescription,
+			StartDate:   work.StartDate.String(),
+			EndDate:     exportEndDate(work.EndDate),
+			Highlights:  Lines(work.Description),
+		})
+	}
+
+	for _, edu := range data.Education {
+		resume.Education = append(resume.Education, JSONResumeEducation{
+			Institution: edu.Institution,
+			URL:         edu.InstitutionURL,
+			Area:        edu.Major,
+			StudyType:   edu.Degree,
+			StartDate:   edu.StartDate.String(),
+			EndDate:     exportEndDate(edu.EndDate),
+			Score:       edu.GPA,
+			Courses:     Lines(edu.Description),
+		})
+	}
+
+	for _, project := range data.Projects {
+		var roles []string
+		if project.Role != "" {
+			roles = []string{project.Role}
+		}
+		resume.Projects = append(resume.Projects, JSONResumeProject{
+			Name:       project.Name,
+			Highlights: Lines(project.Description),
+			StartDate:  project.StartDate.String(),
+			EndDate:    exportEndDate(project.EndDate),
+			URL:        project.URL,
+			Roles:      roles,
+		})
+	}
+
+	for _, certificate := range data.Certificates {
+		resume.Certificates = append(resume.Certificates, JSONResumeCertificate{
+			Name:   certificate.Name,
+			Date:   certificate.StartDate.String(),
+			Issuer: certificate.Issuer,
+			URL:    certificate.URL,
+		})
+	}
+
+	for _, achievement := range data.Achievements {
+		resume.Awards = append(resume.Awards, JSONResumeAward{
+			Title:   achievement.Name,
+			Summary: achievement.Description,
+		})
+	}
+
+	// Levelled skills become their own entries; the rest are grouped by
+	// category as keywords.
+	groups := make(map[string]int)
+	for _, skill := range data.Skills {
+		if skill.Level > 0 {
+			resume.Skills = append(resume.Skills, JSONResumeSkill{Name: skill.Name, Level: formatSkillLevel(skill.Level)})
+			continue
+		}
+		name := skill.Category
+		if name == "" {
+			name = "Skills"
+		}
+		index, exists := groups[name]
+		if !exists {
+			index = len(resume.Skills)
+			groups[name] = index
+			resume.Skills = append(resume.Skills, JSONResumeSkill{Name: name})
+		}
+		resume.Skills[index].Keywords = append(resume.Skills[index].Keywords, skill.Name)
+	}
+
+	for _, language := range data.Languages {
+		resume.Languages = append(resume.Languages, JSONResumeLanguage{Language: language.Name, Fluency: language.Fluency})
+	}
+
+	for _, interest := range data.Interests {
+		resume.Interests = append(resume.Interests, JSONResumeInterest{Name: interest})
+	}
+
+	return resume
+}
+
+// importDate parses a JSON Resume date. Dates that do not parse are dropped,
+// since the schema only allows ISO 8601 dates.
+func importDate(value string) Date {
+	date, err := ParseDate(value)
+	if err != nil {
+		return Date{}
+	}
+	return date
+}
+
+// importEndDate marks an entry with a start date but no end date as ongoing,
+// which JSON Resume expresses by leaving endDate empty.
+func importEndDate(startDate, endDate string) Date {
+	if endDate == "" && startDate != "" {
+		return Present
+	}
+	return importDate(endDate)
+}
+
+func exportEndDate(endDate Date) string {
+	if endDate.Present {
+		return ""
+	}
+	return endDate.String()
+}
+
+// joinDescription turns a summary and highlights into the free-text
+// description format the forms use: the summary line followed by bullets.
+func joinDescription(summary string, highlights []string) string {
+	var lines []string
+	if summary != "" {
+		lines = append(lines, summary)
+	}
+	for _, highlight := range highlights {
+		lines = append(lines, "- "+highlight)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	var nonEmpty []string
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, sep)
+}
+
+func knownCategory(name string) string {
+	for _, category := range []string{CategoryProgramming, CategoryTechnologies, CategoryExpertise, CategoryMethodology, CategoryTools} {
+		if strings.EqualFold(name, category) {
+			return category
+		}
+	}
+	return ""
+}
+
+func parseSkillLevel(level string) int {
+	if n, err := strconv.Atoi(strings.TrimSpace(level)); err == nil && n >= 1 && n <= len(skillLevels) {
+		return n
+	}
+	for i, name := range skillLevels {
+		if strings.EqualFold(level, name) {
+			return i + 1
+		}
+	}
+	if strings.EqualFold(level, "Master") {
+		return len(skillLevels)
+	}
+	return 0
+}
+
+func formatSkillLevel(level int) string {
+	if level < 1 || level > len(skillLevels) {
+		return ""
+	}
+	return skillLevels[level-1]
+}
@@ -0,0 +1,136 @@
+package cv_test
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+)
+
+const sampleJSONResume = `{
+  "basics": {
+    "name": "Jane Doe",
+    "label": "Backend Engineer",
+    "email": "jane@example.com",
+    "phone": "+49 123 456",
+    "url": "https://jane.dev",
+    "summary": "Builds reliable systems.",
+    "location": {"city": "Berlin", "countryCode": "DE"},
+    "profiles": [
+      {"network": "LinkedIn", "username": "janedoe", "url": "https://www.linkedin.com/in/janedoe"},
+      {"network": "GitHub", "username": "janedoe", "url": "https://github.com/janedoe"}
+    ]
+  },
+  "work": [{
+    "name": "Acme",
+    "position": "Senior Engineer",
+    "startDate": "2021-03",
+    "summary": "Platform team.",
+    "highlights": ["Cut latency by 40%", "Led the migration"]
+  }],
+  "education": [{
+    "institution": "TU Berlin",
+    "studyType": "M.Sc.",
+    "area": "Computer Science",
+    "startDate": "2015",
+    "endDate": "2017",
+    "score": "1.3"
+  }],
+  "projects": [{"name": "mycv", "roles": ["Maintainer"], "highlights": ["Typst CVs"]}],
+  "awards": [{"title": "Hackathon winner", "summary": "First place"}],
+  "skills": [
+    {"name": "Programming Languages", "keywords": ["Go", "Python"]},
+    {"name": "Web", "keywords": ["React"]},
+    {"name": "Kubernetes", "level": "Expert"}
+  ],
+  "languages": [{"language": "German", "fluency": "Native"}],
+  "interests": [{"name": "Climbing"}]
+}`
+
+func TestReadJSONResume(t *testing.T) {
+	t.Parallel()
+	data, err := cv.ReadJSONResume(strings.NewReader(sampleJSONResume))
+	if err != nil {
+		t.Fatalf("ReadJSONResume failed: %v", err)
+	}
+
+	if data.Person.Name != "Jane Doe" || data.Person.Title != "Backend Engineer" {
+		t.Errorf("unexpected person: %+v", data.Person)
+	}
+	if data.Contact.Location != "Berlin, DE" {
+		t.Errorf("Location = %q, want %q", data.Contact.Location, "Berlin, DE")
+	}
+	if data.Contact.LinkedIn.URL != "https://www.linkedin.com/in/janedoe" || data.Contact.GitHub.Label != "janedoe" {
+		t.Errorf("unexpected profiles: %+v %+v", data.Contact.LinkedIn, data.Contact.GitHub)
+	}
+
+	if len(data.Experience) != 1 {
+		t.Fatalf("expected 1 experience entry, got %d", len(data.Experience))
+	}
+	work := data.Experience[0]
+	if !work.EndDate.Present {
+		t.Errorf("missing endDate should import as Present, got %q", work.EndDate)
+	}
+	if want := "Platform team.\n- Cut latency by 40%\n- Led the migration"; work.Description != want {
+		t.Errorf("Description = %q, want %q", work.Description, want)
+	}
+
+	if edu := data.Education[0]; edu.Degree != "M.Sc." || edu.Major != "Computer Science" || edu.GPA != "1.3" {
+		t.Errorf("unexpected education: %+v", edu)
+	}
+
+	if got := data.SkillNames(cv.CategoryProgramming); !slices.Equal(got, []string{"Go", "Python"}) {
+		t.Errorf("programming skills = %q", got)
+	}
+	if got := data.SkillNames(""); !slices.Equal(got, []string{"React"}) {
+		t.Errorf("uncategorized skills = %q", got)
+	}
+	if expertise := data.Skills[len(data.Skills)-1]; expertise.Category != cv.CategoryExpertise || expertise.Level != 5 {
+		t.Errorf("unexpected levelled skill: %+v", expertise)
+	}
+
+	if len(data.Achievements) != 1 || data.Languages[0].String() != "German (Native)" || data.Interests[0] != "Climbing" {
+		t.Errorf("unexpected achievements, languages or interests: %+v %+v %+v", data.Achievements, data.Languages, data.Interests)
+	}
+}
+
+func TestReadJSONResumeInvalid(t *testing.T) {
+	t.Parallel()
+	if _, err := cv.ReadJSONResume(strings.NewReader("{not json")); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
+
+func TestJSONResumeRoundTrip(t *testing.T) {
+	t.Parallel()
+	data, err := cv.ReadJSONResume(strings.NewReader(sampleJSONResume))
+	if err != nil {
+		t.Fatalf("ReadJSONResume failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := cv.WriteJSONResume(&buf, data); err != nil {
+		t.Fatalf("WriteJSONResume failed: %v", err)
+	}
+
+	resume := cv.ToJSONResume(data)
+	if resume.Work[0].EndDate != "" {
+		t.Errorf("Present should export as an empty endDate, got %q", resume.Work[0].EndDate)
+	}
+	if !slices.Equal(resume.Work[0].Highlights, []string{"Platform team.", "Cut latency by 40%", "Led the migration"}) {
+		t.Errorf("unexpected highlights: %q", resume.Work[0].Highlights)
+	}
+
+	again, err := cv.ReadJSONResume(&buf)
+	if err != nil {
+		t.Fatalf("reading exported resume failed: %v", err)
+	}
+	if again.Person != data.Person || again.Contact.Email != data.Contact.Email {
+		t.Errorf("round trip changed basics: %+v", again.Person)
+	}
+	if !slices.Equal(again.Skills, data.Skills) {
+		t.Errorf("round trip changed skills: %+v, want %+v", again.Skills, data.Skills)
+	}
+}
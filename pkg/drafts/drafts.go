@@ -0,0 +1,137 @@
+// Package drafts stores named, unfinished form submissions so users can
+// return to a CV and keep editing it. Drafts belong to an owner, which the
+// server ties to a browser cookie.
+package drafts
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"time"
+)
+
+// Form names of the draft's own fields. They are not stored with the values.
+const (
+	IDField   = "draft_id"
+	NameField = "draft_name"
+)
+
+// ErrNotFound is returned for drafts that do not exist or belong to another
+// owner.
+var ErrNotFound = errors.New("draft not found")
+
+var idPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// Draft is the form values of one CV, saved under a name.
+type Draft struct {
+	ID          string     `json:"id"`
+	Owner       string     `json:"owner"`
+	Name        string     `json:"name"`
+	TemplateKey string     `json:"template"`
+	Values      url.Values `json:"values"`
+	// Public drafts are shown to anyone as a profile page.
+	Public    bool      `json:"public,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Store saves drafts. Every method is scoped to one owner.
+type Store interface {
+	// List returns the owner's drafts, most recently updated first.
+	List(ctx context.Context, owner string) ([]Draft, error)
+	Get(ctx context.Context, owner, id string) (Draft, error)
+	// Save creates the draft under a new ID when its ID is empty, and
+	// otherwise replaces the owner's draft with that ID, returning ErrNotFound
+	// when the owner has none. It returns the draft as stored, with its ID and
+	// UpdatedAt set. IDs are unique across all owners.
+	Save(ctx context.Context, draft Draft) (Draft, error)
+	Delete(ctx context.Context, owner, id string) error
+	// GetPublic returns a public draft whoever asks, as ErrNotFound otherwise.
+	GetPublic(ctx context.Context, id string) (Draft, error)
+}
+
+// NewID returns a random identifier for a draft or an owner.
+func NewID() string {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		// crypto/rand does not fail on supported platforms
+		panic(fmt.Sprintf("generating draft ID: %v", err))
+	}
+	return hex.EncodeToString(bytes)
+}
+
+// ValidID reports whether id was returned by NewID. Owner and draft IDs
+// arrive from the client, so stores reject anything else.
+func ValidID(id string) bool {
+	return idPattern.MatchString(id)
+}
+
+// FormValues returns the submitted values worth keeping in a draft: the
+// draft's own fields are dropped.
+func FormValues(values url.Values) url.Values {
+	kept := url.Values{}
+	for key, value := range values {
+		if key != IDField && key != NameField {
+			kept[key] = append([]string(nil), value...)
+		}
+	}
+	return kept
+}
+
+// Duplicate saves a copy of a draft under a new ID.
+func Duplicate(ctx context.Context, store Store, owner, id string) (Draft, error) {
+	draft, err := store.Get(ctx, owner, id)
+	if err != nil {
+		return Draft{}, err
+	}
+	draft.ID = ""
+	draft.Name = "Copy of " + draft.Name
+	draft.Public = false
+	draft.Values = FormValues(draft.Values)
+	return store.Save(ctx, draft)
+}
+
+// Publish shows a draft as a public profile, or hides it again.
+func Publish(ctx context.Context, store Store, owner, id string, public bool) (Draft, error) {
+	draft, err := store.Get(ctx, owner, id)
+	if err != nil {
+		return Draft{}, err
+	}
+	draft.Public = public
+	return store.Save(ctx, draft)
+}
+
+// newUniqueID returns an ID for a new draft that taken reports as unused.
+func newUniqueID(taken func(id string) bool) string {
+	for {
+		if id := NewID(); !taken(id) {
+			return id
+		}
+	}
+}
+
+// prepare checks a draft before saving, sets its timestamp and copies its
+// values so the caller's map is not shared with the store. The store assigns
+// the ID of new drafts.
+func prepare(draft Draft) (Draft, error) {
+	if !ValidID(draft.Owner) {
+		return Draft{}, fmt.Errorf("invalid draft owner %q", draft.Owner)
+	}
+	if draft.ID != "" && !ValidID(draft.ID) {
+		return Draft{}, ErrNotFound
+	}
+	draft.Values = FormValues(draft.Values)
+	draft.UpdatedAt = time.Now().UTC()
+	return draft, nil
+}
+
+// sortDrafts orders drafts the way List returns them.
+func sortDrafts(list []Draft) {
+	slices.SortFunc(list, func(a, b Draft) int {
+		return b.UpdatedAt.Compare(a.UpdatedAt)
+	})
+}
@@ -0,0 +1,251 @@
+package drafts_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/drafts"
+)
+
+func stores(t *testing.T) map[string]drafts.Store {
+	t.Helper()
+	fileStore, err := drafts.NewFileStore(filepath.Join(t.TempDir(), "drafts"))
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %v", err)
+	}
+	return map[string]drafts.Store{
+		"memory": drafts.NewMemoryStore(),
+		"file":   fileStore,
+	}
+}
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			owner, other := drafts.NewID(), drafts.NewID()
+
+			values := url.Values{"name": {"Jane Doe"}, drafts.IDField: {"x"}, drafts.NameField: {"y"}}
+			first, err := store.Save(ctx, drafts.Draft{Owner: owner, Name: "First", TemplateKey: "basic", Values: values})
+			if err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+			if !drafts.ValidID(first.ID) || first.UpdatedAt.IsZero() {
+				t.Errorf("Save did not assign an ID and time: %+v", first)
+			}
+
+			got, err := store.Get(ctx, owner, first.ID)
+			if err != nil {
+				t.Fatalf("Get failed: %v", err)
+			}
+			if got.Name != "First" || got.TemplateKey != "basic" || got.Values.Get("name") != "Jane Doe" {
+				t.Errorf("Unexpected draft: %+v", got)
+			}
+			if got.Values.Has(drafts.IDField) || got.Values.Has(drafts.NameField) {
+				t.Errorf("Draft fields should not be stored with the values: %v", got.Values)
+			}
+
+			// Drafts of other owners are invisible
+			if _, err := store.Get(ctx, other, first.ID); !errors.Is(err, drafts.ErrNotFound) {
+				t.Errorf("Expected ErrNotFound for another owner, got %v", err)
+			}
+			if list, _ := store.List(ctx, other); len(list) != 0 {
+				t.Errorf("Expected no drafts for another owner, got %d", len(list))
+			}
+
+			copied, err := drafts.Duplicate(ctx, store, owner, first.ID)
+			if err != nil {
+				t.Fatalf("Duplicate failed: %v", err)
+			}
+			if copied.ID == first.ID || copied.Name != "Copy of First" || copied.Values.Get("name") != "Jane Doe" {
+				t.Errorf("Unexpected copy: %+v", copied)
+			}
+
+			first.Name = "Renamed"
+			if _, err := store.Save(ctx, first); err != nil {
+				t.Fatalf("Save of existing draft failed: %v", err)
+			}
+			list, err := store.List(ctx, owner)
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			if len(list) != 2 || list[0].Name != "Renamed" || list[1].ID != copied.ID {
+				t.Errorf("Expected the renamed draft first, got %+v", list)
+			}
+
+			if err := store.Delete(ctx, owner, first.ID); err != nil {
+				t.Fatalf("Delete failed: %v", err)
+			}
+			if err := store.Delete(ctx, owner, first.ID); !errors.Is(err, drafts.ErrNotFound) {
+				t.Errorf("Expected ErrNotFound deleting twice, got %v", err)
+			}
+			if list, _ := store.List(ctx, owner); len(list) != 1 {
+				t.Errorf("Expected one draft after delete, got %d", len(list))
+			}
+		})
+	}
+}
+
+func TestGetPublic(t *testing.T) {
+	t.Parallel()
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			owner := drafts.NewID()
+
+			draft, err := store.Save(ctx, drafts.Draft{Owner: owner, Name: "Profile", TemplateKey: "basic"})
+			if err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+			if _, err := store.GetPublic(ctx, draft.ID); !errors.Is(err, drafts.ErrNotFound) {
+				t.Errorf("Expected a private draft to be hidden, got %v", err)
+			}
+
+			if _, err := drafts.Publish(ctx, store, owner, draft.ID, true); err != nil {
+				t.Fatalf("Publish failed: %v", err)
+			}
+			public, err := store.GetPublic(ctx, draft.ID)
+			if err != nil || public.Owner != owner || public.Name != "Profile" {
+				t.Errorf("GetPublic = %+v, %v", public, err)
+			}
+
+			copied, err := drafts.Duplicate(ctx, store, owner, draft.ID)
+			if err != nil {
+				t.Fatalf("Duplicate failed: %v", err)
+			}
+			if copied.Public {
+				t.Error("A copy of a public draft should start private")
+			}
+
+			if _, err := drafts.Publish(ctx, store, owner, draft.ID, false); err != nil {
+				t.Fatalf("Unpublish failed: %v", err)
+			}
+			if _, err := store.GetPublic(ctx, draft.ID); !errors.Is(err, drafts.ErrNotFound) {
+				t.Errorf("Expected an unpublished draft to be hidden, got %v", err)
+			}
+			if _, err := store.GetPublic(ctx, "*"); !errors.Is(err, drafts.ErrNotFound) {
+				t.Errorf("Expected ErrNotFound for an invalid ID, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSaveKeepsIDsToTheirOwner(t *testing.T) {
+	t.Parallel()
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			owner, attacker := drafts.NewID(), drafts.NewID()
+
+			victim, err := store.Save(ctx, drafts.Draft{Owner: owner, Name: "Victim", TemplateKey: "basic"})
+			if err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+			if _, err := drafts.Publish(ctx, store, owner, victim.ID, true); err != nil {
+				t.Fatalf("Publish failed: %v", err)
+			}
+
+			// Another owner cannot claim the ID, nor create drafts under IDs of
+			// their choosing
+			claimed := drafts.Draft{ID: victim.ID, Owner: attacker, Name: "Attacker", Public: true}
+			if _, err := store.Save(ctx, claimed); !errors.Is(err, drafts.ErrNotFound) {
+				t.Errorf("Expected ErrNotFound saving another owner's ID, got %v", err)
+			}
+			if _, err := store.Save(ctx, drafts.Draft{ID: drafts.NewID(), Owner: attacker}); !errors.Is(err, drafts.ErrNotFound) {
+				t.Errorf("Expected ErrNotFound saving an unknown ID, got %v", err)
+			}
+			if list, _ := store.List(ctx, attacker); len(list) != 0 {
+				t.Errorf("Expected no drafts for the other owner, got %+v", list)
+			}
+
+			public, err := store.GetPublic(ctx, victim.ID)
+			if err != nil || public.Owner != owner || public.Name != "Victim" {
+				t.Errorf("GetPublic = %+v, %v", public, err)
+			}
+		})
+	}
+}
+
+func TestStoreReturnsCopies(t *testing.T) {
+	t.Parallel()
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			owner := drafts.NewID()
+
+			saved, err := store.Save(ctx, drafts.Draft{Owner: owner, Name: "Kept", Values: url.Values{"name": {"Jane"}}})
+			if err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+			saved.Values.Set("name", "Changed after save")
+
+			list, err := store.List(ctx, owner)
+			if err != nil || len(list) != 1 {
+				t.Fatalf("List = %+v, %v", list, err)
+			}
+			list[0].Values.Set("name", "Changed after list")
+			list[0].Values["name"][0] = "Changed in place"
+
+			got, err := store.Get(ctx, owner, saved.ID)
+			if err != nil || got.Values.Get("name") != "Jane" {
+				t.Errorf("Expected the stored values to be unchanged, got %v, %v", got.Values, err)
+			}
+		})
+	}
+}
+
+func TestStoreRejectsInvalidIDs(t *testing.T) {
+	t.Parallel()
+	for name, store := range stores(t) {
+		ctx := context.Background()
+		if _, err := store.Save(ctx, drafts.Draft{Owner: "../escape"}); err == nil {
+			t.Errorf("%s: expected an error for an invalid owner", name)
+		}
+		if _, err := store.Save(ctx, drafts.Draft{Owner: drafts.NewID(), ID: "../../etc/passwd"}); !errors.Is(err, drafts.ErrNotFound) {
+			t.Errorf("%s: expected ErrNotFound for an invalid ID, got %v", name, err)
+		}
+		if _, err := store.Get(ctx, "..", "x"); !errors.Is(err, drafts.ErrNotFound) {
+			t.Errorf("%s: expected ErrNotFound for invalid IDs, got %v", name, err)
+		}
+	}
+}
+
+func TestFileStorePersists(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dir := t.TempDir()
+	owner := drafts.NewID()
+
+	store, err := drafts.NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %v", err)
+	}
+	saved, err := store.Save(ctx, drafts.Draft{Owner: owner, Name: "Kept", Values: url.Values{"name": {"Jane"}}})
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// A new store over the same directory sees the draft, as after a restart
+	reopened, err := drafts.NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %v", err)
+	}
+	got, err := reopened.Get(ctx, owner, saved.ID)
+	if err != nil || got.Name != "Kept" || got.Values.Get("name") != "Jane" {
+		t.Errorf("Draft was not persisted: %+v, %v", got, err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, owner))
+	if err != nil || len(entries) != 1 {
+		t.Errorf("Expected only the draft file, got %v, %v", entries, err)
+	}
+}
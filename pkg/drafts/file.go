@@ -0,0 +1,193 @@
+package drafts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const draftExt = ".json"
+
+// FileStore keeps each draft in a JSON file at <dir>/<owner>/<id>.json, so
+// drafts survive restarts.
+type FileStore struct {
+	dir   string
+	mutex sync.RWMutex
+}
+
+// NewFileStore returns a store in dir, creating the directory if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create drafts directory: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (f *FileStore) List(_ context.Context, owner string) ([]Draft, error) {
+	if !ValidID(owner) {
+		return []Draft{}, nil
+	}
+
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	entries, err := os.ReadDir(filepath.Join(f.dir, owner))
+	if errors.Is(err, fs.ErrNotExist) {
+		return []Draft{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list drafts: %w", err)
+	}
+
+	list := make([]Draft, 0, len(entries))
+	for _, entry := range entries {
+		id, found := strings.CutSuffix(entry.Name(), draftExt)
+		if !found || !ValidID(id) {
+			continue
+		}
+		draft, err := f.read(owner, id)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, draft)
+	}
+	sortDrafts(list)
+	return list, nil
+}
+
+func (f *FileStore) Get(_ context.Context, owner, id string) (Draft, error) {
+	if !ValidID(owner) || !ValidID(id) {
+		return Draft{}, ErrNotFound
+	}
+
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	return f.read(owner, id)
+}
+
+func (f *FileStore) Save(_ context.Context, draft Draft) (Draft, error) {
+	draft, err := prepare(draft)
+	if err != nil {
+		return Draft{}, err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if draft.ID == "" {
+		draft.ID = newUniqueID(func(id string) bool {
+			matches, err := filepath.Glob(f.path("*", id))
+			return err != nil || len(matches) > 0
+		})
+	} else if _, err := os.Stat(f.path(draft.Owner, draft.ID)); errors.Is(err, fs.ErrNotExist) {
+		return Draft{}, ErrNotFound
+	} else if err != nil {
+		return Draft{}, fmt.Errorf("failed to save draft: %w", err)
+	}
+
+	data, err := json.MarshalIndent(draft, "", "  ")
+	if err != nil {
+		return Draft{}, fmt.Errorf("failed to encode draft: %w", err)
+	}
+
+	dir := filepath.Join(f.dir, draft.Owner)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return Draft{}, fmt.Errorf("failed to create drafts directory: %w", err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a partial draft
+	tmp, err := os.CreateTemp(dir, "draft-*.tmp")
+	if err != nil {
+		return Draft{}, fmt.Errorf("failed to save draft: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return Draft{}, fmt.Errorf("failed to save draft: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return Draft{}, fmt.Errorf("failed to save draft: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.path(draft.Owner, draft.ID)); err != nil {
+		return Draft{}, fmt.Errorf("failed to save draft: %w", err)
+	}
+	return draft, nil
+}
+
+func (f *FileStore) Delete(_ context.Context, owner, id string) error {
+	if !ValidID(owner) || !ValidID(id) {
+		return ErrNotFound
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	err := os.Remove(f.path(owner, id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete draft: %w", err)
+	}
+	return nil
+}
+
+// GetPublic finds the draft among every owner's directory. Save keeps draft
+// IDs unique, so at most one owner has it.
+func (f *FileStore) GetPublic(_ context.Context, id string) (Draft, error) {
+	if !ValidID(id) {
+		return Draft{}, ErrNotFound
+	}
+
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	matches, err := filepath.Glob(f.path("*", id))
+	if err != nil {
+		return Draft{}, fmt.Errorf("failed to find draft: %w", err)
+	}
+	for _, match := range matches {
+		owner := filepath.Base(filepath.Dir(match))
+		if !ValidID(owner) {
+			continue
+		}
+		draft, err := f.read(owner, id)
+		if err != nil {
+			return Draft{}, err
+		}
+		if draft.Public {
+			return draft, nil
+		}
+	}
+	return Draft{}, ErrNotFound
+}
+
+// path returns the file of a draft. owner and id must be valid IDs, which
+// keeps the path inside the store's directory.
+func (f *FileStore) path(owner, id string) string {
+	return filepath.Join(f.dir, owner, id+draftExt)
+}
+
+func (f *FileStore) read(owner, id string) (Draft, error) {
+	// #nosec G304 - owner and id are validated hex IDs
+	data, err := os.ReadFile(f.path(owner, id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Draft{}, ErrNotFound
+	}
+	if err != nil {
+		return Draft{}, fmt.Errorf("failed to read draft: %w", err)
+	}
+
+	var draft Draft
+	if err := json.Unmarshal(data, &draft); err != nil {
+		return Draft{}, fmt.Errorf("failed to decode draft %s: %w", id, err)
+	}
+	return draft, nil
+}
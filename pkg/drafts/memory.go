@@ -0,0 +1,99 @@
+package drafts
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryStore keeps drafts in memory. They are lost when the server stops.
+type MemoryStore struct {
+	mutex  sync.RWMutex
+	drafts map[string]map[string]Draft
+	// owners maps each draft ID to its owner.
+	owners map[string]string
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{drafts: make(map[string]map[string]Draft), owners: make(map[string]string)}
+}
+
+func (m *MemoryStore) List(_ context.Context, owner string) ([]Draft, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	list := make([]Draft, 0, len(m.drafts[owner]))
+	for _, draft := range m.drafts[owner] {
+		// Callers get copies, so changing them leaves the store alone
+		draft.Values = FormValues(draft.Values)
+		list = append(list, draft)
+	}
+	sortDrafts(list)
+	return list, nil
+}
+
+func (m *MemoryStore) Get(_ context.Context, owner, id string) (Draft, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	draft, exists := m.drafts[owner][id]
+	if !exists {
+		return Draft{}, ErrNotFound
+	}
+	draft.Values = FormValues(draft.Values)
+	return draft, nil
+}
+
+func (m *MemoryStore) Save(_ context.Context, draft Draft) (Draft, error) {
+	draft, err := prepare(draft)
+	if err != nil {
+		return Draft{}, err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if draft.ID == "" {
+		draft.ID = newUniqueID(func(id string) bool {
+			_, taken := m.owners[id]
+			return taken
+		})
+	} else if m.owners[draft.ID] != draft.Owner {
+		return Draft{}, ErrNotFound
+	}
+
+	if m.drafts[draft.Owner] == nil {
+		m.drafts[draft.Owner] = make(map[string]Draft)
+	}
+	m.drafts[draft.Owner][draft.ID] = draft
+	m.owners[draft.ID] = draft.Owner
+	draft.Values = FormValues(draft.Values)
+	return draft, nil
+}
+
+func (m *MemoryStore) Delete(_ context.Context, owner, id string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, exists := m.drafts[owner][id]; !exists {
+		return ErrNotFound
+	}
+	delete(m.drafts[owner], id)
+	delete(m.owners, id)
+	return nil
+}
+
+func (m *MemoryStore) GetPublic(_ context.Context, id string) (Draft, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	owner, exists := m.owners[id]
+	if !exists {
+		return Draft{}, ErrNotFound
+	}
+	draft := m.drafts[owner][id]
+	if !draft.Public {
+		return Draft{}, ErrNotFound
+	}
+	draft.Values = FormValues(draft.Values)
+	return draft, nil
+}
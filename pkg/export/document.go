@@ -0,0 +1,286 @@
+// Package export renders CVs as plain text and Markdown, for applicant
+// tracking systems that cannot read designed PDFs, and as DOCX for recruiters
+// who edit CVs in Word. It lays the CV model out as a Document once, without
+// Typst, and each format writes the Document.
+// Public profile pages are built from the same Document.
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+	"github.com/AlexTLDR/mycv.quest/pkg/richtext"
+	"github.com/AlexTLDR/mycv.quest/pkg/utils"
+)
+
+// Format is a format a CV can be exported as without Typst.
+type Format string
+
+const (
+	Text     Format = "text"
+	Markdown Format = "markdown"
+	DOCX     Format = "docx"
+)
+
+// Formats lists the export formats in the order they are offered.
+var Formats = []Format{Text, Markdown, DOCX}
+
+// ParseFormat returns the format named by s.
+func ParseFormat(s string) (Format, error) {
+	switch format := Format(s); format {
+	case Text, Markdown, DOCX:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown export format %q (want text, markdown or docx)", s)
+	}
+}
+
+// Extension returns the extension of the format's files, without the dot.
+func (f Format) Extension() string {
+	switch f {
+	case Markdown:
+		return "md"
+	case DOCX:
+		return "docx"
+	default:
+		return "txt"
+	}
+}
+
+// ContentType returns the MIME type of the format's files.
+func (f Format) ContentType() string {
+	switch f {
+	case Markdown:
+		return "text/markdown; charset=utf-8"
+	case DOCX:
+		return "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+// Render exports a CV in format f.
+func Render(f Format, data *cv.CV) ([]byte, error) {
+	doc := Build(data)
+	switch f {
+	case Markdown:
+		return doc.Markdown(), nil
+	case DOCX:
+		return doc.DOCX()
+	default:
+		return doc.Text(), nil
+	}
+}
+
+// Document is a CV laid out for export: a header followed by the sections
+// that have content, in the order recruiters read them.
+type Document struct {
+	Name     string
+	Title    string
+	Contacts []Link
+	Sections []Section
+	// Paper and Margins are the CV's page setup, for formats with pages.
+	Paper   string
+	Margins string
+}
+
+// Link is a piece of text with an optional URL.
+type Link struct {
+	Text string
+	URL  string
+}
+
+// Section is a titled part of the CV. It holds a paragraph, entries or
+// items, depending on the section.
+type Section struct {
+	Title     string
+	Paragraph []richtext.Node
+	Entries   []Entry
+	Items     []Item
+}
+
+// Entry is a dated position, degree, project or certificate.
+type Entry struct {
+	Title    string
+	Subtitle Link
+	Location string
+	Dates    string
+	Details  []richtext.Node
+}
+
+// Item is a line of a list section, with an optional label such as a skill
+// category.
+type Item struct {
+	Label string
+	Text  string
+}
+
+// Build lays a CV out as a Document, listing entries newest first and
+// formatting dates in the CV's language.
+func Build(data *cv.CV) Document {
+	data = data.SortedByDate()
+	dates := dateFormatter(data.Layout)
+
+	doc := Document{
+		Name:    data.Person.Name,
+		Title:   firstNonEmpty(data.Person.Title, data.Person.Position),
+		Paper:   data.Layout.Paper,
+		Margins: data.Layout.Margins,
+	}
+	for _, contact := range []Link{
+		{Text: data.Contact.Email, URL: mailto(data.Contact.Email)},
+		{Text: data.Contact.Phone},
+		{Text: data.Contact.Location},
+		webLink(data.Contact.LinkedIn, utils.LinkedInURL(data.Contact.LinkedIn.URL)),
+		webLink(data.Contact.GitHub, utils.NormalizeURL(data.Contact.GitHub.URL)),
+		webLink(data.Contact.Website, utils.NormalizeURL(data.Contact.Website.URL)),
+	} {
+		if contact.Text != "" {
+			doc.Contacts = append(doc.Contacts, contact)
+		}
+	}
+
+	doc.add(Section{Title: "Summary", Paragraph: richtext.Tree(data.Person.Summary)})
+	doc.add(Section{Title: "Objective", Paragraph: richtext.Tree(data.Person.Objective)})
+
+	experience := Section{Title: "Experience"}
+	for _, job := range data.Experience {
+		company := job.Company
+		if job.Product != "" {
+			company = joinNonEmpty(", ", company, job.Product)
+		}
+		experience.Entries = append(experience.Entries, Entry{
+			Title:    job.Title,
+			Subtitle: Link{Text: company, URL: utils.NormalizeURL(job.CompanyURL)},
+			Location: job.Location,
+			Dates:    dates(job.StartDate, job.EndDate),
+			Details:  richtext.Tree(job.Description),
+		})
+	}
+	doc.add(experience)
+
+	education := Section{Title: "Education"}
+	for _, edu := range data.Education {
+		title := joinNonEmpty(", ", edu.Degree, edu.Major, edu.Track)
+		if edu.GPA != "" {
+			title += " (GPA " + edu.GPA + ")"
+		}
+		education.Entries = append(education.Entries, Entry{
+			Title:    title,
+			Subtitle: Link{Text: edu.Institution, URL: utils.NormalizeURL(edu.InstitutionURL)},
+			Location: edu.Location,
+			Dates:    dates(edu.StartDate, edu.EndDate),
+			Details:  richtext.Tree(edu.Description),
+		})
+	}
+	doc.add(education)
+
+	projects := Section{Title: "Projects"}
+	for _, project := range data.Projects {
+		projects.Entries = append(projects.Entries, Entry{
+			Title:    project.Name,
+			Subtitle: Link{Text: project.Role, URL: utils.NormalizeURL(project.URL)},
+			Dates:    dates(project.StartDate, project.EndDate),
+			Details:  richtext.Tree(project.Description),
+		})
+	}
+	doc.add(projects)
+
+	doc.add(Section{Title: "Skills", Items: skillItems(data.Skills)})
+
+	certificates := Section{Title: "Certificates"}
+	for _, certificate := range data.Certificates {
+		certificates.Entries = append(certificates.Entries, Entry{
+			Title:    certificate.Name,
+			Subtitle: Link{Text: certificate.Issuer, URL: utils.NormalizeURL(certificate.URL)},
+			Dates:    dates(certificate.StartDate, certificate.EndDate),
+		})
+	}
+	doc.add(certificates)
+
+	languages := Section{Title: "Languages"}
+	for _, language := range data.Languages {
+		languages.Items = append(languages.Items, Item{Text: language.String()})
+	}
+	doc.add(languages)
+
+	achievements := Section{Title: "Achievements"}
+	for _, achievement := range data.Achievements {
+		achievements.Entries = append(achievements.Entries, Entry{
+			Title:   achievement.Name,
+			Details: richtext.Tree(achievement.Description),
+		})
+	}
+	doc.add(achievements)
+
+	if len(data.Interests) > 0 {
+		doc.add(Section{Title: "Interests", Items: []Item{{Text: strings.Join(data.Interests, ", ")}}})
+	}
+
+	return doc
+}
+
+// add appends a section unless it is empty.
+func (d *Document) add(section Section) {
+	if len(section.Paragraph) > 0 || len(section.Entries) > 0 || len(section.Items) > 0 {
+		d.Sections = append(d.Sections, section)
+	}
+}
+
+// skillItems lists the skills of each category on one line, in the order the
+// categories first appear. Uncategorized skills get no label.
+func skillItems(skills []cv.Skill) []Item {
+	var items []Item
+	index := map[string]int{}
+	for _, skill := range skills {
+		i, exists := index[skill.Category]
+		if !exists {
+			i = len(items)
+			index[skill.Category] = i
+			items = append(items, Item{Label: skill.Category})
+		}
+		items[i].Text = joinNonEmpty(", ", items[i].Text, skill.Name)
+	}
+	return items
+}
+
+// dateFormatter returns a function formatting date ranges in the layout's
+// language and date format.
+func dateFormatter(layout cv.Layout) func(start, end cv.Date) string {
+	lang := firstNonEmpty(strings.ToLower(layout.Lang), "en")
+	format := firstNonEmpty(layout.DateFormat, cv.DateShort)
+	return func(start, end cv.Date) string {
+		return joinNonEmpty(" – ", start.Format(format, lang), end.Format(format, lang))
+	}
+}
+
+func webLink(link cv.Link, url string) Link {
+	return Link{Text: firstNonEmpty(link.Label, link.URL), URL: url}
+}
+
+func mailto(email string) string {
+	if email == "" {
+		return ""
+	}
+	return "mailto:" + email
+}
+
+func joinNonEmpty(sep string, values ...string) string {
+	var parts []string
+	for _, value := range values {
+		if value != "" {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
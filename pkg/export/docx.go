@@ -0,0 +1,311 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+	"github.com/AlexTLDR/mycv.quest/pkg/richtext"
+)
+
+// Page sizes and margins of DOCX files, in twentieths of a point.
+var (
+	docxPapers = map[string][2]int{
+		cv.PaperA4:       {11906, 16838},
+		cv.PaperUSLetter: {12240, 15840},
+		cv.PaperUSLegal:  {12240, 20160},
+	}
+	docxMargins = map[string]int{
+		cv.MarginsNarrow: 720,
+		cv.MarginsNormal: 1134,
+		cv.MarginsWide:   1440,
+	}
+)
+
+// DOCX writes the document as a Word file laid out like the basic template:
+// the name, title and contact line centred at the top, ruled section headings,
+// entries with their dates right-aligned and descriptions as bullet lists.
+// The contact line is a paragraph of its own, so recruiters can remove it.
+func (d Document) DOCX() ([]byte, error) {
+	w := &docxWriter{links: map[string]string{}}
+	w.body(d)
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, part := range []struct{ name, content string }{
+		{"[Content_Types].xml", docxContentTypes},
+		{"_rels/.rels", docxPackageRels},
+		{"docProps/core.xml", docxCore(d.Name)},
+		{"word/_rels/document.xml.rels", w.relationships()},
+		{"word/document.xml", w.document.String()},
+		{"word/styles.xml", docxStyles},
+		{"word/numbering.xml", docxNumbering},
+	} {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+		if _, err := file.Write([]byte(xml.Header + part.content)); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write DOCX: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// docxWriter builds word/document.xml and collects the targets of its links,
+// which the package lists as relationships.
+type docxWriter struct {
+	document strings.Builder
+	// links maps each target to its relationship ID, in order of appearance.
+	links   map[string]string
+	targets []string
+	// tabStop is where dates are right-aligned: the right margin.
+	tabStop int
+}
+
+// runStyle is the character formatting of a run of text.
+type runStyle struct {
+	bold, italic, code, link bool
+}
+
+func (w *docxWriter) body(d Document) {
+	width, height := docxPapers[cv.PaperA4][0], docxPapers[cv.PaperA4][1]
+	if size, ok := docxPapers[d.Paper]; ok {
+		width, height = size[0], size[1]
+	}
+	margin, ok := docxMargins[d.Margins]
+	if !ok {
+		margin = docxMargins[cv.MarginsNormal]
+	}
+	w.tabStop = width - 2*margin
+
+	w.document.WriteString(`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><w:body>`)
+
+	if d.Name != "" {
+		w.paragraph("Title", w.run(d.Name, runStyle{}))
+	}
+	if d.Title != "" {
+		w.paragraph("Subtitle", w.run(d.Title, runStyle{}))
+	}
+	var contacts []string
+	for _, contact := range d.Contacts {
+		contacts = append(contacts, w.link(contact, runStyle{}))
+	}
+	if len(contacts) > 0 {
+		w.paragraph("Contacts", strings.Join(contacts, w.run(" | ", runStyle{})))
+	}
+
+	for _, section := range d.Sections {
+		w.paragraph("Heading1", w.run(section.Title, runStyle{}))
+		for _, node := range section.Paragraph {
+			w.paragraph("Normal", w.spans(node.Spans, runStyle{}))
+			w.list(node.Children, 0)
+		}
+		for _, entry := range section.Entries {
+			w.entry(entry)
+		}
+		for _, item := range section.Items {
+			var runs string
+			if item.Label != "" {
+				runs = w.run(item.Label+": ", runStyle{bold: true})
+			}
+			w.paragraph("Normal", runs+w.run(item.Text, runStyle{}))
+		}
+	}
+
+	fmt.Fprintf(&w.document, `<w:sectPr><w:pgSz w:w="%d" w:h="%d"/><w:pgMar w:top="%d" w:right="%d" w:bottom="%d" w:left="%d" w:header="708" w:footer="708" w:gutter="0"/></w:sectPr>`,
+		width, height, margin, margin, margin, margin)
+	w.document.WriteString(`</w:body></w:document>`)
+}
+
+// entry writes the title line of an entry with its dates at the right margin,
+// then its location and details.
+func (w *docxWriter) entry(entry Entry) {
+	runs := w.run(entry.Title, runStyle{bold: true})
+	if entry.Title != "" && entry.Subtitle.Text != "" {
+		runs += w.run(", ", runStyle{})
+	}
+	runs += w.link(entry.Subtitle, runStyle{})
+	if entry.Dates != "" {
+		runs += `<w:r><w:tab/></w:r>` + w.run(entry.Dates, runStyle{})
+	}
+	fmt.Fprintf(&w.document, `<w:p><w:pPr><w:pStyle w:val="Entry"/><w:tabs><w:tab w:val="right" w:pos="%d"/></w:tabs></w:pPr>%s</w:p>`, w.tabStop, runs)
+
+	if entry.Location != "" {
+		w.paragraph("Location", w.run(entry.Location, runStyle{}))
+	}
+	w.list(entry.Details, 0)
+}
+
+// list writes lines as bullets, nesting their children one level deeper.
+func (w *docxWriter) list(nodes []richtext.Node, level int) {
+	for _, node := range nodes {
+		fmt.Fprintf(&w.document, `<w:p><w:pPr><w:pStyle w:val="ListBullet"/><w:numPr><w:ilvl w:val="%d"/><w:numId w:val="1"/></w:numPr></w:pPr>%s</w:p>`,
+			min(level, 8), w.spans(node.Spans, runStyle{}))
+		w.list(node.Children, level+1)
+	}
+}
+
+func (w *docxWriter) paragraph(style, runs string) {
+	fmt.Fprintf(&w.document, `<w:p><w:pPr><w:pStyle w:val="%s"/></w:pPr>%s</w:p>`, style, runs)
+}
+
+// spans writes spans as runs, adding each span's formatting to style.
+func (w *docxWriter) spans(spans []richtext.Span, style runStyle) string {
+	var b strings.Builder
+	for _, span := range spans {
+		switch span.Kind {
+		case richtext.Strong:
+			inner := style
+			inner.bold = true
+			b.WriteString(w.spans(span.Children, inner))
+		case richtext.Emph:
+			inner := style
+			inner.italic = true
+			b.WriteString(w.spans(span.Children, inner))
+		case richtext.Code:
+			inner := style
+			inner.code = true
+			b.WriteString(w.run(span.Text, inner))
+		case richtext.Link:
+			inner := style
+			inner.link = true
+			b.WriteString(w.hyperlink(span.URL, w.spans(span.Children, inner)))
+		case richtext.Text:
+			b.WriteString(w.run(span.Text, style))
+		default:
+			b.WriteString(w.run(span.Text, style))
+		}
+	}
+	return b.String()
+}
+
+// link writes a link, or plain text when it has no URL.
+func (w *docxWriter) link(link Link, style runStyle) string {
+	if link.URL == "" {
+		return w.run(link.Text, style)
+	}
+	style.link = true
+	return w.hyperlink(link.URL, w.run(link.Text, style))
+}
+
+// hyperlink wraps runs in a link to url, registered as a relationship.
+func (w *docxWriter) hyperlink(url, runs string) string {
+	id, exists := w.links[url]
+	if !exists {
+		id = "rIdLink" + strconv.Itoa(len(w.targets)+1)
+		w.links[url] = id
+		w.targets = append(w.targets, url)
+	}
+	return `<w:hyperlink r:id="` + id + `" w:history="1">` + runs + `</w:hyperlink>`
+}
+
+func (w *docxWriter) run(text string, style runStyle) string {
+	if text == "" {
+		return ""
+	}
+	var props strings.Builder
+	if style.link {
+		props.WriteString(`<w:rStyle w:val="Hyperlink"/>`)
+	}
+	if style.code {
+		props.WriteString(`<w:rFonts w:ascii="Consolas" w:hAnsi="Consolas" w:cs="Consolas"/>`)
+	}
+	if style.bold {
+		props.WriteString(`<w:b/>`)
+	}
+	if style.italic {
+		props.WriteString(`<w:i/>`)
+	}
+	rPr := ""
+	if props.Len() > 0 {
+		rPr = "<w:rPr>" + props.String() + "</w:rPr>"
+	}
+	return `<w:r>` + rPr + `<w:t xml:space="preserve">` + escapeXML(text) + `</w:t></w:r>`
+}
+
+// relationships lists the parts and link targets document.xml refers to.
+func (w *docxWriter) relationships() string {
+	var b strings.Builder
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	b.WriteString(`<Relationship Id="rIdStyles" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`)
+	b.WriteString(`<Relationship Id="rIdNumbering" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/numbering" Target="numbering.xml"/>`)
+	for _, target := range w.targets {
+		fmt.Fprintf(&b, `<Relationship Id="%s" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" Target="%s" TargetMode="External"/>`,
+			w.links[target], escapeXML(target))
+	}
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
+
+func docxCore(name string) string {
+	return `<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/">` +
+		`<dc:title>` + escapeXML(name) + `</dc:title><dc:creator>` + escapeXML(name) + `</dc:creator></cp:coreProperties>`
+}
+
+// escapeXML escapes text for XML content and attributes. Characters XML
+// cannot hold are replaced.
+func escapeXML(s string) string {
+	var b strings.Builder
+	// Writing to a strings.Builder does not fail
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+const docxContentTypes = `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>` +
+	`<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>` +
+	`<Override PartName="/word/numbering.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.numbering+xml"/>` +
+	`<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>` +
+	`</Types>`
+
+const docxPackageRels = `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>` +
+	`</Relationships>`
+
+// docxStyles are the paragraph and character styles the document uses, so
+// recruiters can restyle every heading or entry at once in Word. Word rejects
+// properties out of the schema's order, so keep their order when editing.
+const docxStyles = `<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+	`<w:docDefaults><w:rPrDefault><w:rPr><w:rFonts w:ascii="Calibri" w:hAnsi="Calibri" w:cs="Calibri"/><w:sz w:val="21"/><w:szCs w:val="21"/><w:lang w:val="en-US"/></w:rPr></w:rPrDefault>` +
+	`<w:pPrDefault><w:pPr><w:spacing w:after="60" w:line="264" w:lineRule="auto"/></w:pPr></w:pPrDefault></w:docDefaults>` +
+	`<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/><w:qFormat/></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Title"><w:name w:val="Title"/><w:basedOn w:val="Normal"/><w:next w:val="Subtitle"/><w:qFormat/>` +
+	`<w:pPr><w:spacing w:after="40"/><w:jc w:val="center"/></w:pPr><w:rPr><w:b/><w:sz w:val="48"/><w:szCs w:val="48"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Subtitle"><w:name w:val="Subtitle"/><w:basedOn w:val="Normal"/><w:qFormat/>` +
+	`<w:pPr><w:spacing w:after="80"/><w:jc w:val="center"/></w:pPr><w:rPr><w:sz w:val="26"/><w:szCs w:val="26"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:customStyle="1" w:styleId="Contacts"><w:name w:val="Contacts"/><w:basedOn w:val="Normal"/>` +
+	`<w:pPr><w:spacing w:after="120"/><w:jc w:val="center"/></w:pPr><w:rPr><w:sz w:val="19"/><w:szCs w:val="19"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/>` +
+	`<w:pPr><w:keepNext/><w:pBdr><w:bottom w:val="single" w:sz="6" w:space="1" w:color="000000"/></w:pBdr><w:spacing w:before="240" w:after="80"/><w:outlineLvl w:val="0"/></w:pPr>` +
+	`<w:rPr><w:b/><w:caps/><w:spacing w:val="10"/><w:sz w:val="24"/><w:szCs w:val="24"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:customStyle="1" w:styleId="Entry"><w:name w:val="Entry"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/>` +
+	`<w:pPr><w:keepNext/><w:spacing w:before="120" w:after="0"/></w:pPr></w:style>` +
+	`<w:style w:type="paragraph" w:customStyle="1" w:styleId="Location"><w:name w:val="Location"/><w:basedOn w:val="Normal"/>` +
+	`<w:pPr><w:keepNext/><w:spacing w:after="40"/></w:pPr><w:rPr><w:i/><w:color w:val="555555"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="ListBullet"><w:name w:val="List Bullet"/><w:basedOn w:val="Normal"/><w:pPr><w:spacing w:after="20"/></w:pPr></w:style>` +
+	`<w:style w:type="character" w:styleId="Hyperlink"><w:name w:val="Hyperlink"/><w:rPr><w:color w:val="1F4E79"/><w:u w:val="single"/></w:rPr></w:style>` +
+	`</w:styles>`
+
+// docxNumbering defines the bullets of description lists, one per nesting
+// level.
+var docxNumbering = func() string {
+	var b strings.Builder
+	b.WriteString(`<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:abstractNum w:abstractNumId="0"><w:multiLevelType w:val="hybridMultilevel"/>`)
+	for level, bullet := range []string{"•", "◦", "▪", "•", "◦", "▪", "•", "◦", "▪"} {
+		fmt.Fprintf(&b, `<w:lvl w:ilvl="%d"><w:start w:val="1"/><w:numFmt w:val="bullet"/><w:lvlText w:val="%s"/><w:lvlJc w:val="left"/><w:pPr><w:ind w:left="%d" w:hanging="283"/></w:pPr></w:lvl>`,
+			level, bullet, 360*(level+1))
+	}
+	b.WriteString(`</w:abstractNum><w:num w:numId="1"><w:abstractNumId w:val="0"/></w:num></w:numbering>`)
+	return b.String()
+}()
@@ -0,0 +1,198 @@
+package export_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+	"github.com/AlexTLDR/mycv.quest/pkg/export"
+)
+
+// render exports the CV, failing the test on error.
+func render(t *testing.T, format export.Format, data *cv.CV) []byte {
+	t.Helper()
+	content, err := export.Render(format, data)
+	if err != nil {
+		t.Fatalf("Render(%s) failed: %v", format, err)
+	}
+	return content
+}
+
+func testCV() *cv.CV {
+	return &cv.CV{
+		Person: cv.Person{Name: "Jane Smith", Title: "Senior Developer", Summary: "Builds *reliable* systems."},
+		Contact: cv.Contact{
+			Email:    "jane@example.com",
+			Location: "Austin, TX",
+			LinkedIn: cv.Link{URL: "janesmith"},
+			GitHub:   cv.Link{URL: "github.com/janesmith"},
+		},
+		Experience: []cv.Experience{
+			{Title: "Intern", Company: "Startup", StartDate: cv.MustParseDate("2019-03"), EndDate: cv.MustParseDate("2019-10")},
+			{
+				Title:       "Senior Developer",
+				Company:     "Innovation Labs",
+				Location:    "Remote",
+				StartDate:   cv.MustParseDate("2023-01"),
+				EndDate:     cv.Present,
+				Description: "Cut **latency** by 40%\n  - rewrote the `cache`\nSee [write-up](https://blog.dev/post)",
+			},
+		},
+		Skills: []cv.Skill{
+			{Name: "Go", Category: cv.CategoryProgramming},
+			{Name: "Kubernetes", Category: cv.CategoryTechnologies},
+			{Name: "Rust", Category: cv.CategoryProgramming},
+		},
+		Languages: []cv.Language{{Name: "German", Fluency: "native"}},
+	}
+}
+
+func TestBuildSectionOrder(t *testing.T) {
+	t.Parallel()
+	doc := export.Build(testCV())
+
+	var titles []string
+	for _, section := range doc.Sections {
+		titles = append(titles, section.Title)
+	}
+	if got := strings.Join(titles, ","); got != "Summary,Experience,Skills,Languages" {
+		t.Errorf("Sections = %s, want only the filled ones in order", got)
+	}
+	if entries := doc.Sections[1].Entries; entries[0].Title != "Senior Developer" || entries[0].Dates != "Jan 2023 – Present" {
+		t.Errorf("Expected the current job first with its dates, got %+v", entries[0])
+	}
+	if items := doc.Sections[2].Items; len(items) != 2 || items[0].Text != "Go, Rust" {
+		t.Errorf("Expected skills grouped by category, got %+v", items)
+	}
+}
+
+func TestText(t *testing.T) {
+	t.Parallel()
+	text := string(render(t, export.Text, testCV()))
+
+	for _, expected := range []string{
+		"JANE SMITH\nSenior Developer\n",
+		"jane@example.com | Austin, TX | https://www.linkedin.com/in/janesmith | https://github.com/janesmith\n",
+		"\nSUMMARY\nBuilds reliable systems.\n",
+		"Senior Developer, Innovation Labs\nRemote | Jan 2023 – Present\n- Cut latency by 40%\n  - rewrote the cache\n- See write-up (https://blog.dev/post)\n",
+		"Programming Languages: Go, Rust\n",
+		"German (native)\n",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Text missing %q:\n%s", expected, text)
+		}
+	}
+	if strings.ContainsAny(text, "*`#") {
+		t.Errorf("Text should carry no markup:\n%s", text)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	t.Parallel()
+	data := testCV()
+	data.Person.Name = "Jane *Star* Smith"
+	markdown := string(render(t, export.Markdown, data))
+
+	for _, expected := range []string{
+		"# Jane \\*Star\\* Smith\n\n**Senior Developer**\n\n",
+		"[jane@example.com](<mailto:jane@example.com>) · Austin, TX · [janesmith](<https://www.linkedin.com/in/janesmith>)",
+		"## Summary\n\nBuilds *reliable* systems.\n\n",
+		"### Senior Developer, Innovation Labs\n\n*Remote · Jan 2023 – Present*\n\n",
+		"- Cut **latency** by 40%\n  - rewrote the `cache`\n- See [write-up](<https://blog.dev/post>)\n",
+		"- **Programming Languages:** Go, Rust\n",
+	} {
+		if !strings.Contains(markdown, expected) {
+			t.Errorf("Markdown missing %q:\n%s", expected, markdown)
+		}
+	}
+}
+
+func TestDOCX(t *testing.T) {
+	t.Parallel()
+	data := testCV()
+	data.Person.Name = "Jane <Smith> & Co"
+	data.Layout.Paper = cv.PaperUSLetter
+	content := render(t, export.DOCX, data)
+
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		t.Fatalf("DOCX is not a zip archive: %v", err)
+	}
+	parts := map[string]string{}
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", file.Name, err)
+		}
+		part, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", file.Name, err)
+		}
+		// Every part must be well-formed XML
+		decoder := xml.NewDecoder(bytes.NewReader(part))
+		for {
+			if _, err := decoder.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%s is not well-formed: %v", file.Name, err)
+			}
+		}
+		parts[file.Name] = string(part)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "word/styles.xml", "word/numbering.xml"} {
+		if _, exists := parts[name]; !exists {
+			t.Errorf("DOCX missing part %s", name)
+		}
+	}
+	document := parts["word/document.xml"]
+	for _, expected := range []string{
+		`<w:pStyle w:val="Title"/></w:pPr><w:r><w:t xml:space="preserve">Jane &lt;Smith&gt; &amp; Co</w:t></w:r>`,
+		`<w:pStyle w:val="Contacts"/>`,
+		`<w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t xml:space="preserve">Experience</w:t>`,
+		`<w:r><w:tab/></w:r><w:r><w:t xml:space="preserve">Jan 2023 – Present</w:t></w:r>`,
+		`<w:ilvl w:val="1"/>`,
+		`<w:r><w:rPr><w:b/></w:rPr><w:t xml:space="preserve">latency</w:t></w:r>`,
+		`<w:pgSz w:w="12240" w:h="15840"/>`,
+	} {
+		if !strings.Contains(document, expected) {
+			t.Errorf("document.xml missing %s", expected)
+		}
+	}
+	if !strings.Contains(parts["word/_rels/document.xml.rels"], `Target="https://blog.dev/post" TargetMode="External"`) {
+		t.Errorf("Expected links as external relationships:\n%s", parts["word/_rels/document.xml.rels"])
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+	if format, err := export.ParseFormat("markdown"); err != nil || format.Extension() != "md" {
+		t.Errorf("ParseFormat(markdown) = %q, %v", format, err)
+	}
+	if _, err := export.ParseFormat("pdf"); err == nil {
+		t.Error("Expected an error for a format that is not a text export")
+	}
+}
+
+func TestNewPerson(t *testing.T) {
+	t.Parallel()
+	person := export.NewPerson(testCV(), "https://cv.example/profile/1")
+
+	if person.Type != "Person" || person.JobTitle != "Senior Developer" || person.Description != "Builds reliable systems." {
+		t.Errorf("Unexpected person: %+v", person)
+	}
+	if len(person.SameAs) != 2 || person.SameAs[0] != "https://www.linkedin.com/in/janesmith" {
+		t.Errorf("Expected the profile links as sameAs, got %v", person.SameAs)
+	}
+	if len(person.WorksFor) != 1 || person.WorksFor[0].Name != "Innovation Labs" {
+		t.Errorf("Expected only the current employer, got %+v", person.WorksFor)
+	}
+	if person.Address == nil || person.Address.Locality != "Austin, TX" {
+		t.Errorf("Expected the location as address, got %+v", person.Address)
+	}
+}
@@ -0,0 +1,145 @@
+package export
+
+import (
+	"strings"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/richtext"
+)
+
+// markdownSpecial are the characters escaped in Markdown text, so that typed
+// text is never read as formatting or HTML.
+const markdownSpecial = "\\`*_[]<>#|~"
+
+// Markdown writes the document as CommonMark: the name as the top heading,
+// one heading per section and entry, and descriptions as nested lists with
+// their formatting and links kept.
+func (d Document) Markdown() []byte {
+	var b strings.Builder
+	if d.Name != "" {
+		b.WriteString("# " + escapeMarkdown(d.Name) + "\n\n")
+	}
+	if d.Title != "" {
+		b.WriteString("**" + escapeMarkdown(d.Title) + "**\n\n")
+	}
+	var contacts []string
+	for _, contact := range d.Contacts {
+		contacts = append(contacts, markdownLink(contact))
+	}
+	if len(contacts) > 0 {
+		b.WriteString(strings.Join(contacts, " · ") + "\n\n")
+	}
+
+	for _, section := range d.Sections {
+		b.WriteString("## " + section.Title + "\n\n")
+		if len(section.Paragraph) > 0 {
+			b.WriteString(markdownParagraph(section.Paragraph) + "\n\n")
+		}
+		for _, entry := range section.Entries {
+			b.WriteString("### " + joinNonEmpty(", ", escapeMarkdown(entry.Title), markdownLink(entry.Subtitle)) + "\n\n")
+			if meta := joinNonEmpty(" · ", escapeMarkdown(entry.Location), escapeMarkdown(entry.Dates)); meta != "" {
+				b.WriteString("*" + meta + "*\n\n")
+			}
+			if len(entry.Details) > 0 {
+				markdownList(&b, entry.Details, "")
+				b.WriteString("\n")
+			}
+		}
+		if len(section.Items) > 0 {
+			for _, item := range section.Items {
+				b.WriteString("- ")
+				if item.Label != "" {
+					b.WriteString("**" + escapeMarkdown(item.Label) + ":** ")
+				}
+				b.WriteString(escapeMarkdown(item.Text) + "\n")
+			}
+			b.WriteString("\n")
+		}
+	}
+	return []byte(strings.TrimRight(b.String(), "\n") + "\n")
+}
+
+// markdownList writes lines as a bullet list, nesting their children.
+func markdownList(b *strings.Builder, nodes []richtext.Node, indent string) {
+	for _, node := range nodes {
+		b.WriteString(indent + "- " + markdownSpans(node.Spans) + "\n")
+		markdownList(b, node.Children, indent+"  ")
+	}
+}
+
+// markdownParagraph joins lines and their children into running text.
+func markdownParagraph(nodes []richtext.Node) string {
+	var lines []string
+	for _, node := range nodes {
+		lines = append(lines, markdownSpans(node.Spans))
+		if len(node.Children) > 0 {
+			lines = append(lines, markdownParagraph(node.Children))
+		}
+	}
+	return strings.Join(lines, " ")
+}
+
+// markdownSpans writes spans with Markdown's own markers for their formatting.
+func markdownSpans(spans []richtext.Span) string {
+	var b strings.Builder
+	for _, span := range spans {
+		switch span.Kind {
+		case richtext.Strong:
+			b.WriteString("**" + markdownSpans(span.Children) + "**")
+		case richtext.Emph:
+			b.WriteString("*" + markdownSpans(span.Children) + "*")
+		case richtext.Code:
+			b.WriteString(markdownCode(span.Text))
+		case richtext.Link:
+			b.WriteString("[" + markdownSpans(span.Children) + "](" + markdownURL(span.URL) + ")")
+		case richtext.Text:
+			b.WriteString(escapeMarkdown(span.Text))
+		default:
+			b.WriteString(escapeMarkdown(span.Text))
+		}
+	}
+	return b.String()
+}
+
+// markdownCode writes a code span, fenced with more backticks than the code
+// contains in a row.
+func markdownCode(code string) string {
+	fence := "`"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+		code = " " + code + " "
+	}
+	return fence + code + fence
+}
+
+// markdownLink writes a link, or its escaped text when it has no URL.
+func markdownLink(link Link) string {
+	if link.URL == "" {
+		return escapeMarkdown(link.Text)
+	}
+	return "[" + escapeMarkdown(link.Text) + "](" + markdownURL(link.URL) + ")"
+}
+
+// urlEscaper percent-encodes the characters that would end a link
+// destination in angle brackets.
+var urlEscaper = strings.NewReplacer("<", "%3C", ">", "%3E", "\n", "%0A", "\r", "%0D")
+
+// markdownURL writes a link destination, which may hold spaces and
+// parentheses inside angle brackets.
+func markdownURL(url string) string {
+	return "<" + urlEscaper.Replace(url) + ">"
+}
+
+// escapeMarkdown backslash-escapes the characters Markdown would read as
+// formatting.
+func escapeMarkdown(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(markdownSpecial, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,92 @@
+package export
+
+import (
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+	"github.com/AlexTLDR/mycv.quest/pkg/richtext"
+	"github.com/AlexTLDR/mycv.quest/pkg/utils"
+)
+
+// Person is a CV described as a schema.org Person, for the JSON-LD of public
+// profiles. Search engines read it to show the person's name, role and links.
+type Person struct {
+	Context       string         `json:"@context"`
+	Type          string         `json:"@type"`
+	Name          string         `json:"name"`
+	URL           string         `json:"url,omitempty"`
+	JobTitle      string         `json:"jobTitle,omitempty"`
+	Description   string         `json:"description,omitempty"`
+	Email         string         `json:"email,omitempty"`
+	Telephone     string         `json:"telephone,omitempty"`
+	Address       *Place         `json:"address,omitempty"`
+	SameAs        []string       `json:"sameAs,omitempty"`
+	WorksFor      []Organization `json:"worksFor,omitempty"`
+	AlumniOf      []Organization `json:"alumniOf,omitempty"`
+	KnowsAbout    []string       `json:"knowsAbout,omitempty"`
+	KnowsLanguage []string       `json:"knowsLanguage,omitempty"`
+}
+
+// Place is a schema.org PostalAddress holding the free-form location of a CV.
+type Place struct {
+	Type     string `json:"@type"`
+	Locality string `json:"addressLocality"`
+}
+
+// Organization is a schema.org Organization or EducationalOrganization.
+type Organization struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// NewPerson describes a CV as a schema.org Person whose profile is at url.
+// Current jobs are listed as worksFor and every school as alumniOf.
+func NewPerson(data *cv.CV, url string) Person {
+	person := Person{
+		Context:     "https://schema.org",
+		Type:        "Person",
+		Name:        data.Person.Name,
+		URL:         url,
+		JobTitle:    firstNonEmpty(data.Person.Title, data.Person.Position),
+		Description: textParagraph(richtext.Tree(data.Person.Summary)),
+		Email:       data.Contact.Email,
+		Telephone:   data.Contact.Phone,
+	}
+	if data.Contact.Location != "" {
+		person.Address = &Place{Type: "PostalAddress", Locality: data.Contact.Location}
+	}
+	for _, link := range []string{
+		utils.LinkedInURL(data.Contact.LinkedIn.URL),
+		utils.NormalizeURL(data.Contact.GitHub.URL),
+		utils.NormalizeURL(data.Contact.Website.URL),
+	} {
+		if link != "" {
+			person.SameAs = append(person.SameAs, link)
+		}
+	}
+
+	for _, job := range data.Experience {
+		if job.Company != "" && job.EndDate.Present {
+			person.WorksFor = append(person.WorksFor, Organization{
+				Type: "Organization",
+				Name: job.Company,
+				URL:  utils.NormalizeURL(job.CompanyURL),
+			})
+		}
+	}
+	for _, edu := range data.Education {
+		if edu.Institution != "" {
+			person.AlumniOf = append(person.AlumniOf, Organization{
+				Type: "EducationalOrganization",
+				Name: edu.Institution,
+				URL:  utils.NormalizeURL(edu.InstitutionURL),
+			})
+		}
+	}
+	for _, skill := range data.Skills {
+		person.KnowsAbout = append(person.KnowsAbout, skill.Name)
+	}
+	for _, language := range data.Languages {
+		person.KnowsLanguage = append(person.KnowsLanguage, language.Name)
+	}
+	return person
+}
@@ -0,0 +1,100 @@
+package export
+
+import (
+	"strings"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/richtext"
+)
+
+// Text writes the document as plain text: upper-case section headings,
+// entries as lines of text and descriptions as indented dash lists. Links are
+// written out in full, since parsers look for the URLs themselves.
+func (d Document) Text() []byte {
+	var b strings.Builder
+	writeLine(&b, strings.ToUpper(d.Name))
+	writeLine(&b, d.Title)
+	var contacts []string
+	for _, contact := range d.Contacts {
+		contacts = append(contacts, textLink(contact))
+	}
+	writeLine(&b, strings.Join(contacts, " | "))
+
+	for _, section := range d.Sections {
+		b.WriteString("\n" + strings.ToUpper(section.Title) + "\n")
+		if len(section.Paragraph) > 0 {
+			writeLine(&b, textParagraph(section.Paragraph))
+		}
+		for i, entry := range section.Entries {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			writeLine(&b, joinNonEmpty(", ", entry.Title, textLink(entry.Subtitle)))
+			writeLine(&b, joinNonEmpty(" | ", entry.Location, entry.Dates))
+			textList(&b, entry.Details, "")
+		}
+		for _, item := range section.Items {
+			if item.Label != "" {
+				writeLine(&b, item.Label+": "+item.Text)
+			} else {
+				writeLine(&b, item.Text)
+			}
+		}
+	}
+	return []byte(b.String())
+}
+
+// textList writes lines as a dash list, nesting their children.
+func textList(b *strings.Builder, nodes []richtext.Node, indent string) {
+	for _, node := range nodes {
+		b.WriteString(indent + "- " + textSpans(node.Spans) + "\n")
+		textList(b, node.Children, indent+"  ")
+	}
+}
+
+// textParagraph joins lines and their children into running text.
+func textParagraph(nodes []richtext.Node) string {
+	var lines []string
+	for _, node := range nodes {
+		lines = append(lines, textSpans(node.Spans))
+		if len(node.Children) > 0 {
+			lines = append(lines, textParagraph(node.Children))
+		}
+	}
+	return strings.Join(lines, " ")
+}
+
+// textSpans drops the formatting of spans and spells out link targets.
+func textSpans(spans []richtext.Span) string {
+	var b strings.Builder
+	for _, span := range spans {
+		switch span.Kind {
+		case richtext.Link:
+			b.WriteString(textLink(Link{Text: textSpans(span.Children), URL: span.URL}))
+		case richtext.Strong, richtext.Emph:
+			b.WriteString(textSpans(span.Children))
+		case richtext.Text, richtext.Code:
+			b.WriteString(span.Text)
+		default:
+			b.WriteString(span.Text)
+		}
+	}
+	return b.String()
+}
+
+// textLink writes a link as its text followed by its URL, or just the URL
+// when the text already names it.
+func textLink(link Link) string {
+	url := strings.TrimPrefix(link.URL, "mailto:")
+	switch {
+	case url == "" || strings.Contains(url, link.Text):
+		return firstNonEmpty(url, link.Text)
+	default:
+		return link.Text + " (" + url + ")"
+	}
+}
+
+func writeLine(b *strings.Builder, line string) {
+	if line != "" {
+		b.WriteString(line + "\n")
+	}
+}
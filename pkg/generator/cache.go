@@ -0,0 +1,245 @@
+package generator
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/photo"
+)
+
+// DefaultCacheSize bounds the PDFs a render cache keeps, in bytes.
+const DefaultCacheSize = 64 << 20
+
+// cacheVersion is part of every key, so that changing how PDFs are produced
+// invalidates PDFs cached by an earlier version.
+const cacheVersion = "1"
+
+// CacheStats is a snapshot of a render cache, for monitoring.
+type CacheStats struct {
+	Entries   int
+	Bytes     int64
+	MaxBytes  int64
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// RenderCache keeps compiled PDFs by the hash of everything Typst reads, and
+// evicts the least recently used ones beyond its size bound.
+type RenderCache struct {
+	mutex    sync.Mutex
+	maxBytes int64
+	entries  map[string]*list.Element
+	// order holds the entries from most to least recently used.
+	order *list.List
+	stats CacheStats
+}
+
+type cacheEntry struct {
+	key string
+	pdf []byte
+}
+
+// NewRenderCache returns a cache holding at most maxBytes of PDFs. A cache of
+// size zero stores nothing.
+func NewRenderCache(maxBytes int64) *RenderCache {
+	return &RenderCache{
+		maxBytes: maxBytes,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		stats:    CacheStats{MaxBytes: maxBytes},
+	}
+}
+
+// Get returns the PDF stored under key and counts a hit or a miss.
+func (c *RenderCache) Get(key string) ([]byte, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, exists := c.entries[key]
+	if !exists {
+		c.stats.Misses++
+		return nil, false
+	}
+	c.stats.Hits++
+	c.order.MoveToFront(element)
+	entry, _ := element.Value.(*cacheEntry)
+	return entry.pdf, true
+}
+
+// Put stores a PDF under key, evicting the least recently used PDFs until it
+// fits. PDFs larger than the whole cache are not stored.
+func (c *RenderCache) Put(key string, pdf []byte) {
+	size := int64(len(pdf))
+	if size > c.maxBytes {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, exists := c.entries[key]; exists {
+		c.remove(element)
+	}
+	for c.stats.Bytes+size > c.maxBytes {
+		c.remove(c.order.Back())
+		c.stats.Evictions++
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, pdf: pdf})
+	c.stats.Entries++
+	c.stats.Bytes += size
+}
+
+func (c *RenderCache) remove(element *list.Element) {
+	entry, _ := c.order.Remove(element).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.stats.Entries--
+	c.stats.Bytes -= int64(len(entry.pdf))
+}
+
+// Stats returns the cache's size and counters.
+func (c *RenderCache) Stats() CacheStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.stats
+}
+
+// cacheKey hashes the template key, the output, the fingerprint of the shared
+// directories Typst also reads, such as packages and fonts, and the files
+// Typst compiles: the paths under root, which may be files or directories, in
+// the order given. Directories are walked in lexical order, so equal inputs
+// hash equally.
+func cacheKey(templateKey string, output Output, shared, root string, paths []string) (string, error) {
+	h := sha256.New()
+	writeField(h, []byte(cacheVersion))
+	writeField(h, []byte(templateKey))
+	writeField(h, []byte(output.String()))
+	writeField(h, []byte(shared))
+
+	for _, path := range paths {
+		err := filepath.WalkDir(filepath.Join(root, path), func(file string, entry fs.DirEntry, err error) error {
+			if err != nil || !entry.Type().IsRegular() {
+				return err
+			}
+			rel, err := filepath.Rel(root, file)
+			if err != nil {
+				return err
+			}
+			writeField(h, []byte(filepath.ToSlash(rel)))
+			return hashFile(h, file)
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to hash %s: %w", path, err)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// fingerprintDirs hashes the names, sizes and modification times of the
+// files under dirs. Updating a package or a font changes them, without
+// reading the files. Missing or empty dirs hash as empty.
+func fingerprintDirs(dirs []string) (string, error) {
+	h := sha256.New()
+	for _, dir := range dirs {
+		writeField(h, []byte(dir))
+		if dir == "" {
+			continue
+		}
+		err := fingerprintTree(h, dir, ".", make(map[string]bool))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("failed to fingerprint %s: %w", dir, err)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// fingerprintTree hashes the files under rel in root. Symbolic links are
+// followed, as Typst follows them and packages are often linked into the
+// package directory. ancestors holds the resolved directories being walked,
+// so that a link back to one of them is not followed forever.
+func fingerprintTree(h hash.Hash, root, rel string, ancestors map[string]bool) error {
+	dir := filepath.Join(root, rel)
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+	if ancestors[resolved] {
+		return nil
+	}
+	ancestors[resolved] = true
+	defer delete(ancestors, resolved)
+
+	entries, err := os.ReadDir(resolved)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		name := filepath.Join(rel, entry.Name())
+		info, err := os.Stat(filepath.Join(root, name))
+		if errors.Is(err, fs.ErrNotExist) {
+			// A dangling link, which Typst cannot read either
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if err := fingerprintTree(h, root, name, ancestors); err != nil {
+				return err
+			}
+			continue
+		}
+		writeField(h, []byte(filepath.ToSlash(name)))
+		_ = binary.Write(h, binary.BigEndian, uint64(info.Size()))
+		_ = binary.Write(h, binary.BigEndian, info.ModTime().UnixNano())
+	}
+	return nil
+}
+
+// avatarKey hashes an uploaded photo and the options it is processed with.
+func avatarKey(avatar []byte, options photo.Options) string {
+	h := sha256.New()
+	writeField(h, []byte(cacheVersion))
+	writeField(h, avatar)
+	_ = binary.Write(h, binary.BigEndian, int64(options.Size))
+	if options.Focus != nil {
+		_ = binary.Write(h, binary.BigEndian, options.Focus.X)
+		_ = binary.Write(h, binary.BigEndian, options.Focus.Y)
+	}
+	_ = binary.Write(h, binary.BigEndian, options.PNG)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeField writes a length-prefixed value, so that no two sequences of
+// values hash alike.
+func writeField(h hash.Hash, value []byte) {
+	_ = binary.Write(h, binary.BigEndian, uint64(len(value)))
+	h.Write(value)
+}
+
+func hashFile(h hash.Hash, path string) error {
+	// #nosec G304 - path is inside a template or work directory
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	_ = binary.Write(h, binary.BigEndian, uint64(info.Size()))
+	_, err = io.Copy(h, file)
+	return err
+}
@@ -0,0 +1,51 @@
+package generator_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/generator"
+)
+
+func TestRenderCache(t *testing.T) {
+	t.Parallel()
+	cache := generator.NewRenderCache(10)
+
+	if _, hit := cache.Get("a"); hit {
+		t.Error("Expected a miss on an empty cache")
+	}
+
+	cache.Put("a", []byte("aaaa"))
+	cache.Put("b", []byte("bbbb"))
+	if pdf, hit := cache.Get("a"); !hit || !bytes.Equal(pdf, []byte("aaaa")) {
+		t.Errorf("Expected a hit for a, got %q, %v", pdf, hit)
+	}
+
+	// b is now the least recently used entry and makes room for c
+	cache.Put("c", []byte("cccc"))
+	if _, hit := cache.Get("b"); hit {
+		t.Error("Expected b to be evicted")
+	}
+	if _, hit := cache.Get("a"); !hit {
+		t.Error("Expected a to stay cached")
+	}
+
+	// Replacing an entry does not count it twice
+	cache.Put("c", []byte("cc"))
+	// Too large for the whole cache, so never stored
+	cache.Put("d", []byte("ddddddddddd"))
+
+	want := generator.CacheStats{Entries: 2, Bytes: 6, MaxBytes: 10, Hits: 2, Misses: 2, Evictions: 1}
+	if stats := cache.Stats(); stats != want {
+		t.Errorf("Stats = %+v, want %+v", stats, want)
+	}
+}
+
+func TestRenderCacheDisabled(t *testing.T) {
+	t.Parallel()
+	cache := generator.NewRenderCache(0)
+	cache.Put("a", []byte("pdf"))
+	if _, hit := cache.Get("a"); hit {
+		t.Error("A cache of size zero should store nothing")
+	}
+}
@@ -0,0 +1,201 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+)
+
+// CompileJob is one Typst compilation.
+type CompileJob struct {
+	// Dir is the project directory Typst runs in.
+	Dir string
+	// Input is the entry file, relative to Dir.
+	Input string
+	// PackagePath is the directory Typst resolves package imports from
+	// before downloading them. Empty leaves Typst's default.
+	PackagePath string
+	// FontPath is the directory of fonts Typst uses in place of the system
+	// fonts. Empty uses the system fonts.
+	FontPath string
+	// Output is the file to produce, by default the PDF.
+	Output Output
+	// OutputRoot is the directory under which Typst gets a directory to write
+	// the output to. Empty uses the system's temporary directory.
+	OutputRoot string
+}
+
+// Compiler compiles Typst projects to the job's output: a PDF, one page image
+// or a zip archive of every page image. Failures of the compilation itself
+// are returned as a *CompileError; a compilation stopped by ctx returns an
+// error wrapping ctx's error.
+type Compiler interface {
+	Compile(ctx context.Context, job CompileJob) ([]byte, error)
+}
+
+// ExecCompiler runs the typst binary.
+type ExecCompiler struct {
+	// Binary is the typst executable. Empty means "typst" from PATH.
+	Binary string
+}
+
+func (c ExecCompiler) Compile(ctx context.Context, job CompileJob) ([]byte, error) {
+	binary := c.Binary
+	if binary == "" {
+		binary = "typst"
+	}
+
+	// Typst writes the output to files, which are read back and removed.
+	// Images get one file per page, numbered by Typst.
+	if job.OutputRoot != "" {
+		if err := os.MkdirAll(job.OutputRoot, 0o750); err != nil {
+			return nil, fmt.Errorf("failed to create output root: %w", err)
+		}
+	}
+	outputDir, err := os.MkdirTemp(job.OutputRoot, workDirPrefix+"output-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+	defer os.RemoveAll(outputDir)
+	ext := string(job.Output.Format)
+	if !job.Output.IsImage() {
+		ext = string(FormatPDF)
+	}
+	outputFile := filepath.Join(outputDir, "cv."+ext)
+	if job.Output.IsImage() {
+		outputFile = filepath.Join(outputDir, "page-{p}."+ext)
+	}
+
+	// Validate arguments before executing command
+	if err := validateTypstArgs(job.Input, outputFile); err != nil {
+		return nil, fmt.Errorf("invalid typst arguments: %w", err)
+	}
+
+	args := []string{"compile"}
+	if job.PackagePath != "" {
+		args = append(args, "--package-path", job.PackagePath)
+	}
+	if job.FontPath != "" {
+		// Fonts installed on the host would make the output differ by host
+		args = append(args, "--font-path", job.FontPath, "--ignore-system-fonts")
+	}
+	if job.Output.IsImage() {
+		args = append(args, "--format", ext)
+		if job.Output.Format == FormatPNG {
+			args = append(args, "--ppi", strconv.Itoa(job.Output.dpi()))
+		}
+		if job.Output.Page > 0 {
+			args = append(args, "--pages", strconv.Itoa(job.Output.Page))
+		}
+	}
+	args = append(args, job.Input, outputFile)
+
+	// #nosec G204 - arguments are validated above
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Dir = job.Dir
+
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() != nil {
+		// Typst was stopped by the job's timeout, or because the caller no
+		// longer wants the PDF
+		return nil, fmt.Errorf("typst compilation stopped: %w", ctx.Err())
+	}
+	if err != nil {
+		return nil, &CompileError{Err: err, Output: string(output)}
+	}
+
+	if !job.Output.IsImage() {
+		pdfData, err := os.ReadFile(outputFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read generated PDF: %w", err)
+		}
+		return pdfData, nil
+	}
+	return readPages(outputDir, ext, job.Output.Page)
+}
+
+// readPages reads the page images Typst wrote to dir as page-1.<ext>,
+// page-2.<ext> and so on. A single page is returned as is, every page as a
+// zip archive.
+func readPages(dir, ext string, page int) ([]byte, error) {
+	if page > 0 {
+		// #nosec G304 - the file is in the compilation's output directory
+		data, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("page-%d.%s", page, ext)))
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrPageNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read page %d: %w", page, err)
+		}
+		return data, nil
+	}
+
+	var pages [][]byte
+	for number := 1; ; number++ {
+		// #nosec G304 - the file is in the compilation's output directory
+		data, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("page-%d.%s", number, ext)))
+		if errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read page %d: %w", number, err)
+		}
+		pages = append(pages, data)
+	}
+	return zipPages(pages, ext)
+}
+
+// compileJob returns the compilation of input in dir to output, resolving
+// packages and fonts from the configured directories. Typst writes the output
+// under the work root, where SweepWorkDirs finds what a crash leaves behind.
+func (g *CVGenerator) compileJob(dir, input string, output Output) CompileJob {
+	// Typst runs in dir, so the paths must not be relative
+	return CompileJob{
+		Dir:         dir,
+		Input:       input,
+		PackagePath: absPath(g.config.PackageDir),
+		FontPath:    absPath(g.config.FontDir),
+		Output:      output,
+		OutputRoot:  absPath(g.workRoot()),
+	}
+}
+
+// sharedFingerprint returns the fingerprint of the directories every
+// compilation reads besides its own files, whose changes must invalidate
+// cached output. It is computed once, and again after packages are vendored.
+func (g *CVGenerator) sharedFingerprint() (string, error) {
+	g.sharedMutex.Lock()
+	defer g.sharedMutex.Unlock()
+	if g.shared == "" {
+		shared, err := fingerprintDirs([]string{g.config.PackageDir, g.config.FontDir})
+		if err != nil {
+			return "", err
+		}
+		g.shared = shared
+	}
+	return g.shared, nil
+}
+
+// resetSharedFingerprint makes the next compilation fingerprint the shared
+// directories again.
+func (g *CVGenerator) resetSharedFingerprint() {
+	g.sharedMutex.Lock()
+	defer g.sharedMutex.Unlock()
+	g.shared = ""
+}
+
+// absPath returns path made absolute, or empty if path is empty.
+func absPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
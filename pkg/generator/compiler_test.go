@@ -0,0 +1,103 @@
+package generator_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/generator"
+)
+
+func TestExecCompilerMissingBinary(t *testing.T) {
+	t.Parallel()
+	compiler := generator.ExecCompiler{Binary: "typst-does-not-exist"}
+
+	_, err := compiler.Compile(context.Background(), generator.CompileJob{Dir: t.TempDir(), Input: "main.typ"})
+	var compileErr *generator.CompileError
+	if !errors.As(err, &compileErr) {
+		t.Errorf("Expected a compile error, got: %v", err)
+	}
+}
+
+func TestExecCompilerRejectsInput(t *testing.T) {
+	t.Parallel()
+	compiler := generator.ExecCompiler{}
+
+	if _, err := compiler.Compile(context.Background(), generator.CompileJob{Dir: t.TempDir(), Input: "main.txt"}); err == nil {
+		t.Error("Expected an error for an input that is not a .typ file")
+	}
+}
+
+func TestFakeCompilerRecordsSources(t *testing.T) {
+	t.Parallel()
+	compiler := &generator.FakeCompiler{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := compiler.Compile(ctx, generator.CompileJob{Dir: t.TempDir(), Input: "main.typ"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a canceled compilation, got: %v", err)
+	}
+	if len(compiler.Jobs()) != 0 {
+		t.Error("Canceled compilations should not be recorded")
+	}
+
+	pdf, err := compiler.Compile(context.Background(), generator.CompileJob{Dir: "../../templates/basic/template", Input: "main.typ"})
+	if err != nil || string(pdf) != string(generator.StubPDF) {
+		t.Fatalf("Expected the stub PDF, got %q, %v", pdf, err)
+	}
+	if jobs := compiler.Jobs(); len(jobs) != 1 || len(jobs[0].Sources["main.typ"]) == 0 {
+		t.Errorf("Expected main.typ among the recorded sources, got %+v", jobs)
+	}
+}
+
+func TestExecCompilerImages(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("the stand-in typst is a shell script")
+	}
+
+	// A stand-in typst that records its arguments and writes two pages to
+	// the numbered output path
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "typst")
+	script := "#!/bin/sh\necho \"$@\" > args\nfor last; do :; done\nfor p in 1 2; do echo \"page $p\" > \"$(echo \"$last\" | sed \"s/{p}/$p/\")\"; done\n"
+	if err := os.WriteFile(binary, []byte(script), 0o700); err != nil {
+		t.Fatalf("Failed to write stand-in typst: %v", err)
+	}
+	compiler := generator.ExecCompiler{Binary: binary}
+	outputRoot := filepath.Join(dir, "work")
+	job := generator.CompileJob{Dir: dir, Input: "main.typ", Output: generator.Output{Format: generator.FormatPNG, DPI: 200, Page: 2}, OutputRoot: outputRoot}
+
+	page, err := compiler.Compile(context.Background(), job)
+	if err != nil || string(page) != "page 2\n" {
+		t.Fatalf("Expected the second page, got %q, %v", page, err)
+	}
+	args, _ := os.ReadFile(filepath.Join(dir, "args"))
+	if !strings.Contains(string(args), "--format png --ppi 200 --pages 2 main.typ "+outputRoot+"/cv-output-") {
+		t.Errorf("Unexpected typst arguments: %s", args)
+	}
+	// The output directory is removed once the pages are read
+	if entries, err := os.ReadDir(outputRoot); err != nil || len(entries) != 0 {
+		t.Errorf("Expected an empty output root, got %v, %v", entries, err)
+	}
+
+	job.Output = generator.Output{Format: generator.FormatSVG}
+	archive, err := compiler.Compile(context.Background(), job)
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	if reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive))); err != nil || len(reader.File) != 2 {
+		t.Errorf("Expected a zip archive of two pages, got %v", err)
+	}
+
+	job.Output = generator.Output{Format: generator.FormatSVG, Page: 3}
+	if _, err := compiler.Compile(context.Background(), job); !errors.Is(err, generator.ErrPageNotFound) {
+		t.Errorf("Expected ErrPageNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package generator
+
+import (
+	"net/url"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/schema"
+)
+
+// ValidationError is returned for a submission that fails the template's
+// field checks. Values holds what was submitted, for showing the form again.
+type ValidationError struct {
+	Values url.Values
+	Errors schema.Errors
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid form submission"
+}
+
+// CompileError is returned when Typst fails to compile a CV. Output holds
+// Typst's diagnostics, which are kept out of the message shown to users.
+type CompileError struct {
+	Err    error
+	Output string
+}
+
+func (e *CompileError) Error() string {
+	return "typst compilation failed: " + e.Err.Error()
+}
+
+func (e *CompileError) Unwrap() error {
+	return e.Err
+}
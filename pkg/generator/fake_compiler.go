@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// StubPDF is the PDF a FakeCompiler returns unless told otherwise.
+var StubPDF = []byte("%PDF-1.4\n1 0 obj << /Type /Catalog >> endobj\ntrailer << /Root 1 0 R >>\n%%EOF\n")
+
+// StubPNG and StubSVG are the page images a FakeCompiler returns for every
+// page of image outputs.
+var (
+	StubPNG = []byte("\x89PNG\r\n\x1a\n")
+	StubSVG = []byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`)
+)
+
+// CompiledJob is a compilation recorded by a FakeCompiler.
+type CompiledJob struct {
+	CompileJob
+	// Sources holds every file of the project directory at compile time,
+	// keyed by its slash-separated path relative to the directory.
+	Sources map[string][]byte
+}
+
+// FakeCompiler stands in for Typst in tests. It records the sources of each
+// compilation and returns PDF, or StubPDF when PDF is nil, or Err when set.
+// Image outputs are Pages pages of StubPNG or StubSVG, or one page when Pages
+// is zero.
+type FakeCompiler struct {
+	PDF   []byte
+	Err   error
+	Pages int
+
+	mutex sync.Mutex
+	jobs  []CompiledJob
+}
+
+func (f *FakeCompiler) Compile(ctx context.Context, job CompileJob) ([]byte, error) {
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("typst compilation stopped: %w", ctx.Err())
+	}
+
+	sources := make(map[string][]byte)
+	err := filepath.WalkDir(job.Dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(job.Dir, path)
+		if err != nil {
+			return err
+		}
+		// #nosec G304 - path is inside the job's directory
+		content, err := os.ReadFile(path)
+		sources[filepath.ToSlash(rel)] = content
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sources: %w", err)
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.jobs = append(f.jobs, CompiledJob{CompileJob: job, Sources: sources})
+
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	if job.Output.IsImage() {
+		return f.pages(job.Output)
+	}
+	if f.PDF != nil {
+		return f.PDF, nil
+	}
+	return StubPDF, nil
+}
+
+func (f *FakeCompiler) pages(output Output) ([]byte, error) {
+	page := StubPNG
+	if output.Format == FormatSVG {
+		page = StubSVG
+	}
+	count := max(f.Pages, 1)
+	if output.Page > count {
+		return nil, ErrPageNotFound
+	}
+	if output.Page > 0 {
+		return page, nil
+	}
+	pages := make([][]byte, count)
+	for i := range pages {
+		pages[i] = page
+	}
+	return zipPages(pages, string(output.Format))
+}
+
+// Jobs returns the compilations so far, in order.
+func (f *FakeCompiler) Jobs() []CompiledJob {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]CompiledJob(nil), f.jobs...)
+}
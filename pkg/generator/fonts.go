@@ -0,0 +1,43 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/typst"
+)
+
+// CheckFonts returns an error naming every font a template may set that is
+// neither built into Typst nor in the font directory, since Typst would
+// silently fall back to another font.
+func (g *CVGenerator) CheckFonts() error {
+	available := slices.Clone(typst.EmbeddedFonts)
+	if g.config.FontDir != "" {
+		families, err := typst.FontFamilies(g.config.FontDir)
+		if err != nil {
+			return fmt.Errorf("failed to read fonts: %w", err)
+		}
+		available = append(available, families...)
+	}
+
+	keys := g.config.GetTemplateKeys()
+	slices.Sort(keys)
+
+	var problems []string
+	for _, key := range keys {
+		var missing []string
+		for _, font := range g.config.Templates[key].Fonts {
+			if !typst.HasFont(available, font) {
+				missing = append(missing, font)
+			}
+		}
+		if len(missing) > 0 {
+			problems = append(problems, fmt.Sprintf("template %s uses %s", key, strings.Join(missing, ", ")))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("fonts missing from %q: %s", g.config.FontDir, strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package generator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/config"
+	"github.com/AlexTLDR/mycv.quest/pkg/generator"
+)
+
+func TestCheckFonts(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{
+		Templates: map[string]config.Template{
+			"basic":  {Name: "Basic Resume", Fonts: []string{"New Computer Modern", "libertinus serif"}},
+			"modern": {Name: "Modern Resume", Fonts: []string{"Roboto", "Libertinus Serif"}},
+		},
+		FontDir: t.TempDir(),
+	}
+
+	err := generator.New(cfg).CheckFonts()
+	if err == nil {
+		t.Fatal("Expected an error for a font that is not available")
+	}
+	if !strings.Contains(err.Error(), "template modern uses Roboto") || strings.Contains(err.Error(), "basic") {
+		t.Errorf("Expected only Roboto of the modern template to be missing, got: %v", err)
+	}
+
+	// Fonts built into Typst need no font directory
+	delete(cfg.Templates, "modern")
+	if err := generator.New(cfg).CheckFonts(); err != nil {
+		t.Errorf("Expected the embedded fonts to be available, got: %v", err)
+	}
+}
+
+func TestBundledTemplatesHaveFonts(t *testing.T) {
+	t.Parallel()
+	cfg, err := config.NewConfig("../../templates")
+	if err != nil {
+		t.Fatalf("NewConfig failed: %v", err)
+	}
+	cfg.FontDir = "../../fonts"
+
+	if err := generator.New(cfg).CheckFonts(); err != nil {
+		// The bundled templates offer Roboto and PT Sans, whose files
+		// fonts/README.md lists
+		t.Errorf("Expected every font the templates offer to be in the fonts directory, got: %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package generator
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+	"github.com/AlexTLDR/mycv.quest/pkg/schema"
+)
+
+// ResumeUploadField is the multipart field carrying an optional JSON Resume
+// file that replaces the form fields.
+const ResumeUploadField = "resume_json"
+
+// parseForm parses form data, handling both multipart and regular forms.
+func parseForm(r *http.Request) error {
+	if strings.Contains(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(10 << 20); err != nil { // 10 MB max
+			return fmt.Errorf("failed to parse multipart form: %w", err)
+		}
+		return nil
+	}
+	if err := r.ParseForm(); err != nil {
+		return fmt.Errorf("failed to parse form: %w", err)
+	}
+	return nil
+}
+
+// readUploadedResume returns the CV from an uploaded JSON Resume file. The
+// boolean reports whether a file was uploaded at all. A file that is not a
+// JSON Resume is the user's mistake, so it is a *ValidationError.
+func readUploadedResume(r *http.Request) (*cv.CV, bool, error) {
+	if r.MultipartForm == nil || len(r.MultipartForm.File[ResumeUploadField]) == 0 {
+		return nil, false, nil
+	}
+
+	file, header, err := r.FormFile(ResumeUploadField)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read uploaded resume: %w", err)
+	}
+	defer file.Close()
+
+	if header.Size == 0 {
+		// Browsers submit an empty part when no file was chosen
+		return nil, false, nil
+	}
+
+	data, err := cv.ReadJSONResume(file)
+	if err != nil {
+		return nil, true, &ValidationError{
+			Values: r.Form,
+			Errors: schema.Errors{ResumeUploadField: "This file is not a valid JSON Resume document"},
+		}
+	}
+	return data, true, nil
+}
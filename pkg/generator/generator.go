@@ -2,17 +2,24 @@ package generator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/AlexTLDR/mycv.quest/pkg/config"
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+	"github.com/AlexTLDR/mycv.quest/pkg/export"
+	"github.com/AlexTLDR/mycv.quest/pkg/photo"
+	"github.com/AlexTLDR/mycv.quest/pkg/schema"
 	"github.com/AlexTLDR/mycv.quest/pkg/utils"
 	"github.com/AlexTLDR/mycv.quest/templates"
+	"github.com/a-h/templ"
 )
 
 // validateTypstArgs validates and sanitizes arguments for typst command execution.
@@ -26,8 +33,10 @@ func validateTypstArgs(inputFile, outputFile string) error {
 	if !strings.HasSuffix(inputFile, ".typ") {
 		return fmt.Errorf("input file must have .typ extension")
 	}
-	if !strings.HasSuffix(outputFile, ".pdf") {
-		return fmt.Errorf("output file must have .pdf extension")
+	switch filepath.Ext(outputFile) {
+	case ".pdf", ".png", ".svg":
+	default:
+		return fmt.Errorf("output file must have .pdf, .png or .svg extension")
 	}
 
 	return nil
@@ -35,98 +44,185 @@ func validateTypstArgs(inputFile, outputFile string) error {
 
 const (
 	DefaultAvatarFilename = "avatar.png"
+	// avatarCacheSize bounds the normalized photos kept, in bytes.
+	avatarCacheSize = 16 << 20
 )
 
 type CVGenerator struct {
-	config *config.Config
+	config    *config.Config
+	compiler  Compiler
+	scheduler *Scheduler
+	cache     *RenderCache
+	// avatars keeps normalized photos by the hash of the upload.
+	avatars *RenderCache
+
+	// shared fingerprints the package and font directories, which change
+	// rarely, so compilations do not walk them.
+	sharedMutex sync.Mutex
+	shared      string
+}
+
+// Options holds the shared resources of a generator. Nil fields get the
+// defaults.
+type Options struct {
+	// Compiler compiles the Typst sources, by default with the typst binary.
+	Compiler Compiler
+	// Scheduler runs the Typst compilations.
+	Scheduler *Scheduler
+	// Cache keeps compiled PDFs for identical inputs.
+	Cache *RenderCache
 }
 
+// New returns a generator with the default compile limits and cache.
 func New(cfg *config.Config) *CVGenerator {
+	return NewWithOptions(cfg, Options{})
+}
+
+func NewWithOptions(cfg *config.Config, options Options) *CVGenerator {
+	if options.Compiler == nil {
+		options.Compiler = ExecCompiler{}
+	}
+	if options.Scheduler == nil {
+		scheduler, err := NewScheduler(DefaultSchedulerConfig())
+		if err != nil {
+			// The default configuration is always valid
+			panic(err)
+		}
+		options.Scheduler = scheduler
+	}
+	if options.Cache == nil {
+		options.Cache = NewRenderCache(DefaultCacheSize)
+	}
 	return &CVGenerator{
-		config: cfg,
+		config:    cfg,
+		compiler:  options.Compiler,
+		scheduler: options.Scheduler,
+		cache:     options.Cache,
+		avatars:   NewRenderCache(avatarCacheSize),
 	}
 }
 
-func (cv *CVGenerator) ListTemplates() {
+// Scheduler returns the scheduler compilations run on, for monitoring.
+func (g *CVGenerator) Scheduler() *Scheduler {
+	return g.scheduler
+}
+
+// Cache returns the cache of compiled PDFs, for monitoring.
+func (g *CVGenerator) Cache() *RenderCache {
+	return g.cache
+}
+
+func (g *CVGenerator) ListTemplates() {
 	fmt.Println("Available templates:")
-	for key, template := range cv.config.Templates {
+	for key, template := range g.config.Templates {
 		fmt.Printf("  %s: %s\n", key, template.Name)
 	}
 }
 
-func (cv *CVGenerator) Generate(ctx context.Context, templateKey string) error {
-	template, exists := cv.config.GetTemplate(templateKey)
+// Generate compiles the template's own sources to output and writes the
+// result to the output directory as cv-<template>.<ext>.
+func (g *CVGenerator) Generate(ctx context.Context, templateKey string, output Output) error {
+	template, exists := g.config.GetTemplate(templateKey)
 	if !exists {
 		return fmt.Errorf("template '%s' not found", templateKey)
 	}
+	if err := output.Validate(); err != nil {
+		return err
+	}
 
-	if err := utils.EnsureDir(cv.config.OutputDir); err != nil {
+	if err := utils.EnsureDir(g.config.OutputDir); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
 	if template.NeedsPhoto {
-		if err := cv.CopyPhoto(template.Dir); err != nil {
+		if err := g.CopyPhoto(template.Dir, template.PhotoSize); err != nil {
 			return fmt.Errorf("failed to copy photo for %s template: %w", template.Name, err)
 		}
 	}
 
-	outputFile := filepath.Join(cv.config.OutputDir, fmt.Sprintf("cv-%s.pdf", templateKey))
+	outputFile := filepath.Join(g.config.OutputDir, outputFilename(templateKey, output))
 	absOutputFile, _ := filepath.Abs(outputFile)
 
 	if err := config.ValidateTemplateArgs(template, absOutputFile); err != nil {
 		return fmt.Errorf("invalid template arguments: %w", err)
 	}
 
-	// #nosec G204 - arguments are validated above
-	cmd := exec.CommandContext(ctx, "typst", "compile", template.InputFile, absOutputFile)
-	cmd.Dir = template.Dir
+	shared, err := g.sharedFingerprint()
+	if err != nil {
+		return err
+	}
+	// Typst may read any file of the template directory
+	key, err := cacheKey(templateKey, output, shared, template.Dir, []string{"."})
+	if err != nil {
+		return err
+	}
+	if data, hit := g.cache.Get(key); hit {
+		if err := os.WriteFile(outputFile, data, 0o600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", outputFile, err)
+		}
+		fmt.Printf("CV unchanged, reused the %s output at %s\n", template.Name, outputFile)
+		return nil
+	}
 
-	output, err := cmd.CombinedOutput()
+	data, err := g.compiler.Compile(ctx, g.compileJob(template.Dir, template.InputFile, output))
 	if err != nil {
-		return fmt.Errorf("typst compilation failed for %s: %w\nOutput: %s", template.Name, err, string(output))
+		return fmt.Errorf("failed to generate %s: %w", template.Name, err)
 	}
+	if err := os.WriteFile(outputFile, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputFile, err)
+	}
+	g.cache.Put(key, data)
 
-	fmt.Printf("CV generated successfully using %s template at %s/cv-%s.pdf\n", template.Name, cv.config.OutputDir, templateKey)
+	fmt.Printf("CV generated successfully using %s template at %s\n", template.Name, outputFile)
 	return nil
 }
 
-func (cv *CVGenerator) GetTemplateData() []templates.CVTemplate {
-	templateData := make([]templates.CVTemplate, 0, len(cv.config.Templates))
-
-	descriptions := map[string]string{
-		"vantage": "Clean and professional design with modern typography",
-		"basic":   "Simple and elegant layout perfect for any industry",
-		"modern":  "Contemporary design with visual elements and photo support",
+// outputFilename names the file an output of a template is written to.
+func outputFilename(templateKey string, output Output) string {
+	name := "cv-" + templateKey
+	if output.IsImage() && output.Page > 0 {
+		name += fmt.Sprintf("-page-%d", output.Page)
 	}
+	return name + "." + output.Extension()
+}
 
-	// Map template keys to their example PDF paths
-	examplePDFs := map[string]string{
-		"vantage": "/static/templates/vantage/example.pdf",
-		"basic":   "/static/templates/basic/example-resume.pdf",
-		"modern":  "/static/templates/modern/template/test.pdf",
-	}
+// HasTemplate reports whether a template with the given key is loaded.
+func (g *CVGenerator) HasTemplate(templateKey string) bool {
+	_, exists := g.config.GetTemplate(templateKey)
+	return exists
+}
+
+func (g *CVGenerator) GetTemplateData() []templates.CVTemplate {
+	templateData := make([]templates.CVTemplate, 0, len(g.config.Templates))
+
+	for key, template := range g.config.Templates {
+		description := template.Description
+		if description == "" && template.Renderer != nil {
+			description = template.Renderer.Metadata().Description
+		}
 
-	for key, template := range cv.config.Templates {
 		// Use example PDF for preview instead of generated CV
-		pdfPath := examplePDFs[key]
+		pdfPath := staticPath(template.ExamplePDF)
 		if pdfPath == "" {
 			// Fallback if no example PDF is found
 			pdfPath = fmt.Sprintf("/static/templates/%s/example.pdf", key)
 		}
 
-		thumbnailPath := ""
+		thumbnailPath := staticPath(template.Thumbnail)
 
-		// Check for thumbnail images
-		if _, err := os.Stat(filepath.Join(template.Dir, "thumbnail.png")); err == nil {
-			thumbnailPath = fmt.Sprintf("/static/%s/thumbnail.png", template.Dir)
-		} else if _, err := os.Stat(filepath.Join(template.Dir, "screenshot.png")); err == nil {
-			thumbnailPath = fmt.Sprintf("/static/%s/screenshot.png", template.Dir)
+		// Check for thumbnail images the manifest doesn't declare
+		if thumbnailPath == "" {
+			if _, err := os.Stat(filepath.Join(template.Dir, "thumbnail.png")); err == nil {
+				thumbnailPath = fmt.Sprintf("/static/%s/thumbnail.png", template.Dir)
+			} else if _, err := os.Stat(filepath.Join(template.Dir, "screenshot.png")); err == nil {
+				thumbnailPath = fmt.Sprintf("/static/%s/screenshot.png", template.Dir)
+			}
 		}
 
 		templateData = append(templateData, templates.CVTemplate{
 			Key:           key,
 			Name:          template.Name,
-			Description:   descriptions[key],
+			Description:   description,
 			PDFPath:       pdfPath,
 			ThumbnailPath: thumbnailPath,
 		})
@@ -135,37 +231,424 @@ func (cv *CVGenerator) GetTemplateData() []templates.CVTemplate {
 	return templateData
 }
 
-func (cv *CVGenerator) GenerateFromForm(templateKey string, r *http.Request) ([]byte, error) {
-	template, exists := cv.config.GetTemplate(templateKey)
+// Submission is a checked CV with the photo uploaded along with it, kept to
+// compile the CV again in another format.
+type Submission struct {
+	CV *cv.CV
+	// Avatar is the uploaded photo, normalized by the photo package, or nil
+	// to use the template's default.
+	Avatar []byte
+}
+
+func (g *CVGenerator) GenerateFromForm(templateKey string, r *http.Request) ([]byte, error) {
+	submission, err := g.ReadForm(templateKey, r)
+	if err != nil {
+		return nil, err
+	}
+	return g.GenerateFromSubmission(r.Context(), templateKey, submission, Output{})
+}
+
+// ReadForm parses and checks a submitted form, or the JSON Resume uploaded
+// with it, for the given template.
+func (g *CVGenerator) ReadForm(templateKey string, r *http.Request) (*Submission, error) {
+	template, exists := g.config.GetTemplate(templateKey)
+	if !exists {
+		return nil, fmt.Errorf("template '%s' not found", templateKey)
+	}
+
+	if err := parseForm(r); err != nil {
+		return nil, err
+	}
+
+	// An uploaded JSON Resume takes the place of the form fields. It is
+	// checked like a submitted form, through the fields it fills in.
+	data, uploaded, err := readUploadedResume(r)
+	if err != nil {
+		return nil, err
+	}
+	values := r.Form
+	if uploaded {
+		values = schema.Encode(template.Fields, data)
+	}
+	if errs := schema.CheckValues(template.Fields, values); len(errs) > 0 {
+		return nil, &ValidationError{Values: values, Errors: errs}
+	}
+	if !uploaded {
+		data = schema.Decode(template.Fields, values)
+	}
+
+	submission, err := g.readSubmission(r.Context(), template, data, r)
+	if message := photoError(err); message != "" {
+		return nil, &ValidationError{Values: values, Errors: schema.Errors{"avatar": message}}
+	}
+	return submission, err
+}
+
+// photoError returns the message shown next to the photo field when err
+// rejects the uploaded photo, or an empty string otherwise.
+func photoError(err error) string {
+	switch {
+	case errors.Is(err, photo.ErrUnsupported):
+		return "Upload the photo as a PNG, JPEG or WebP image"
+	case errors.Is(err, photo.ErrTooLarge):
+		return "The photo has too many pixels, please upload a smaller one"
+	default:
+		return ""
+	}
+}
+
+// PreviewFromForm builds a PDF of the form's current state. Unlike
+// GenerateFromForm it does not validate the values, since the form is still
+// being filled in. Cancelling ctx stops Typst, for when the preview is stale.
+func (g *CVGenerator) PreviewFromForm(ctx context.Context, templateKey string, r *http.Request) ([]byte, error) {
+	template, exists := g.config.GetTemplate(templateKey)
+	if !exists {
+		return nil, fmt.Errorf("template '%s' not found", templateKey)
+	}
+
+	if err := parseForm(r); err != nil {
+		return nil, err
+	}
+
+	data, uploaded, err := readUploadedResume(r)
+	if err != nil {
+		return nil, err
+	}
+	if !uploaded {
+		data = schema.Decode(template.Fields, r.Form)
+	}
+
+	submission, err := g.readSubmission(ctx, template, data, r)
+	if err != nil {
+		return nil, err
+	}
+	return g.compile(ctx, template, submission, Output{})
+}
+
+// GenerateFromCV builds a PDF for the given template from an already decoded CV.
+func (g *CVGenerator) GenerateFromCV(ctx context.Context, templateKey string, data *cv.CV) ([]byte, error) {
+	return g.GenerateFromSubmission(ctx, templateKey, &Submission{CV: data}, Output{})
+}
+
+// GenerateFromSubmission compiles a checked submission for the given template
+// to output.
+func (g *CVGenerator) GenerateFromSubmission(ctx context.Context, templateKey string, submission *Submission, output Output) ([]byte, error) {
+	template, exists := g.config.GetTemplate(templateKey)
+	if !exists {
+		return nil, fmt.Errorf("template '%s' not found", templateKey)
+	}
+	if err := output.Validate(); err != nil {
+		return nil, err
+	}
+
+	return g.compile(ctx, template, submission, output)
+}
+
+// GenerateFromFile builds a CV from a JSON Resume file and writes it to the
+// output directory as cv-<template>.<ext>.
+func (g *CVGenerator) GenerateFromFile(ctx context.Context, templateKey, inputFile string, output Output) error {
+	// #nosec G304 - inputFile is supplied by the CLI user
+	file, err := os.Open(inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to open input file: %w", err)
+	}
+	defer file.Close()
+
+	data, err := cv.ReadJSONResume(file)
+	if err != nil {
+		return err
+	}
+
+	content, err := g.GenerateFromSubmission(ctx, templateKey, &Submission{CV: data}, output)
+	if err != nil {
+		return err
+	}
+
+	if err := utils.EnsureDir(g.config.OutputDir); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	outputFile := filepath.Join(g.config.OutputDir, outputFilename(templateKey, output))
+	if err := os.WriteFile(outputFile, content, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputFile, err)
+	}
+
+	fmt.Printf("CV generated successfully from %s at %s\n", inputFile, outputFile)
+	return nil
+}
+
+// ExportFromFile writes a JSON Resume file as plain text, Markdown or DOCX to
+// the output directory as cv-<template>.txt, .md or .docx. No Typst is
+// involved.
+func (g *CVGenerator) ExportFromFile(templateKey, inputFile string, format export.Format) error {
+	if !g.HasTemplate(templateKey) {
+		return fmt.Errorf("template '%s' not found", templateKey)
+	}
+
+	// #nosec G304 - inputFile is supplied by the CLI user
+	file, err := os.Open(inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to open input file: %w", err)
+	}
+	defer file.Close()
+
+	data, err := cv.ReadJSONResume(file)
+	if err != nil {
+		return err
+	}
+
+	if err := utils.EnsureDir(g.config.OutputDir); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	content, err := export.Render(format, data)
+	if err != nil {
+		return err
+	}
+
+	outputFile := filepath.Join(g.config.OutputDir, fmt.Sprintf("cv-%s.%s", templateKey, format.Extension()))
+	if err := os.WriteFile(outputFile, content, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outputFile, err)
+	}
+
+	fmt.Printf("CV exported from %s at %s\n", inputFile, outputFile)
+	return nil
+}
+
+// DecodeForm parses a submitted form for the given template into a CV.
+func (g *CVGenerator) DecodeForm(templateKey string, r *http.Request) (*cv.CV, error) {
+	template, exists := g.config.GetTemplate(templateKey)
 	if !exists {
 		return nil, fmt.Errorf("template '%s' not found", templateKey)
 	}
 
-	// Parse form data - handle both multipart and regular forms
-	if strings.Contains(r.Header.Get("Content-Type"), "multipart/form-data") {
-		if err := r.ParseMultipartForm(10 << 20); err != nil { // 10 MB max
-			return nil, fmt.Errorf("failed to parse multipart form: %w", err)
+	if err := parseForm(r); err != nil {
+		return nil, err
+	}
+
+	return schema.Decode(template.Fields, r.Form), nil
+}
+
+// DecodeValues decodes form values kept for the given template, such as a
+// draft's, into a CV.
+func (g *CVGenerator) DecodeValues(templateKey string, values url.Values) (*cv.CV, error) {
+	template, exists := g.config.GetTemplate(templateKey)
+	if !exists {
+		return nil, fmt.Errorf("template '%s' not found", templateKey)
+	}
+	return schema.Decode(template.Fields, values), nil
+}
+
+// FormValues parses a submitted form for the given template and returns its
+// values without checking them, as kept in a draft.
+func (g *CVGenerator) FormValues(templateKey string, r *http.Request) (url.Values, error) {
+	if _, exists := g.config.GetTemplate(templateKey); !exists {
+		return nil, fmt.Errorf("template '%s' not found", templateKey)
+	}
+
+	if err := parseForm(r); err != nil {
+		return nil, err
+	}
+
+	return r.Form, nil
+}
+
+// GetForm returns the form of the given template, prefilled with the
+// defaults of its fields.
+func (g *CVGenerator) GetForm(templateKey string) (templ.Component, bool) {
+	template, exists := g.config.GetTemplate(templateKey)
+	if !exists {
+		return nil, false
+	}
+	return g.GetFilledForm(templateKey, schema.Defaults(template.Fields), nil)
+}
+
+// GetFilledForm returns the form of the given template showing values, with
+// errs next to the fields they belong to.
+func (g *CVGenerator) GetFilledForm(templateKey string, values url.Values, errs schema.Errors) (templ.Component, bool) {
+	view, exists := g.GetFormView(templateKey, values, errs)
+	if !exists {
+		return nil, false
+	}
+	return templates.SchemaForm(view), true
+}
+
+// GetFormView returns the view GetFilledForm renders, for callers that add
+// to it before rendering.
+func (g *CVGenerator) GetFormView(templateKey string, values url.Values, errs schema.Errors) (templates.FormView, bool) {
+	template, exists := g.config.GetTemplate(templateKey)
+	if !exists {
+		return templates.FormView{}, false
+	}
+	return templates.FormView{
+		Key:    templateKey,
+		Title:  template.Name,
+		Fields: template.Fields,
+		Values: values,
+		Errors: errs,
+	}, true
+}
+
+// readSubmission pairs a CV with the photo uploaded in r, for templates that
+// show one.
+func (g *CVGenerator) readSubmission(ctx context.Context, template config.Template, data *cv.CV, r *http.Request) (*Submission, error) {
+	submission := &Submission{CV: data}
+	if template.NeedsPhoto {
+		avatar, err := readAvatar(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to handle photo upload: %w", err)
 		}
-	} else {
-		if err := r.ParseForm(); err != nil {
-			return nil, fmt.Errorf("failed to parse form: %w", err)
+		if avatar != nil {
+			if submission.Avatar, err = g.normalizeAvatar(ctx, template, data, avatar); err != nil {
+				return nil, err
+			}
 		}
 	}
+	return submission, nil
+}
 
-	// Generate template-specific files and return PDF data
-	switch templateKey {
-	case "basic":
-		return cv.GenerateBasicCV(template, r)
-	case "modern":
-		return cv.GenerateModernCV(template, r)
-	case "vantage":
-		return cv.GenerateVantageCV(template, r)
-	default:
-		return nil, fmt.Errorf("unsupported template: %s", templateKey)
+// normalizeAvatar turns an uploaded photo upright, crops it around the CV's
+// photo focus and scales it to the template's size. Decoding a photo takes
+// as much memory as a compilation, so it runs on the scheduler, and the
+// result is cached since every preview uploads the photo again.
+func (g *CVGenerator) normalizeAvatar(ctx context.Context, template config.Template, data *cv.CV, avatar []byte) ([]byte, error) {
+	focus, err := photo.ParseFocus(data.Theme.PhotoFocus)
+	if err != nil {
+		// The form only offers valid positions, so fall back to the centre
+		focus = photo.Center
+	}
+	options := photo.Options{Size: template.PhotoSize, Focus: &focus}
+
+	key := avatarKey(avatar, options)
+	if normalized, hit := g.avatars.Get(key); hit {
+		return normalized, nil
+	}
+
+	var normalized photo.Photo
+	err = g.scheduler.Run(ctx, func(context.Context) error {
+		normalized, err = photo.Process(avatar, options)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to process photo: %w", err)
+	}
+	g.avatars.Put(key, normalized.Data)
+	return normalized.Data, nil
+}
+
+// compile renders the submitted CV with the template's renderer in a fresh
+// work directory and compiles it to output with Typst.
+func (g *CVGenerator) compile(ctx context.Context, template config.Template, submission *Submission, output Output) ([]byte, error) {
+	if template.Renderer == nil {
+		return nil, fmt.Errorf("template '%s' has no renderer", template.Name)
+	}
+
+	workDir, cleanup, err := g.newWorkDir(template.Renderer.Key())
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	// Copy template files
+	for _, file := range append([]string{template.InputFile}, template.Assets...) {
+		if err := copySource(filepath.Join(template.Dir, file), filepath.Join(workDir, file)); err != nil {
+			return nil, fmt.Errorf("failed to copy %s: %w", file, err)
+		}
+	}
+
+	avatarFilename, err := g.prepareAvatar(template, workDir, submission.Avatar)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate the template's files from the CV, newest entries first
+	files, err := template.Renderer.Render(submission.CV.SortedByDate(), avatarFilename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s template: %w", template.Name, err)
 	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(workDir, name), content, 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+
+	shared, err := g.sharedFingerprint()
+	if err != nil {
+		return nil, err
+	}
+	// The work directory holds everything Typst reads: the template files,
+	// the rendered CV and the avatar
+	key, err := cacheKey(template.Renderer.Key(), output, shared, workDir, []string{"."})
+	if err != nil {
+		return nil, err
+	}
+	if data, hit := g.cache.Get(key); hit {
+		return data, nil
+	}
+
+	var data []byte
+	err = g.scheduler.Run(ctx, func(ctx context.Context) error {
+		var err error
+		data, err = g.compiler.Compile(ctx, g.compileJob(workDir, template.InputFile, output))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	g.cache.Put(key, data)
+
+	fmt.Printf("CV generated successfully in memory\n")
+	return data, nil
 }
 
-func (cv *CVGenerator) CopyPhoto(templateDir string) error {
+// prepareAvatar places the uploaded avatar, or the template's default one, in
+// the work directory and returns its filename.
+func (g *CVGenerator) prepareAvatar(template config.Template, workDir string, avatar []byte) (string, error) {
+	if !template.NeedsPhoto {
+		return "", nil
+	}
+
+	if avatar != nil {
+		filename := avatarFilename(avatar)
+		if err := os.WriteFile(filepath.Join(workDir, filename), avatar, 0o600); err != nil {
+			return "", fmt.Errorf("failed to write avatar: %w", err)
+		}
+		return filename, nil
+	}
+
+	// Copy template's default avatar only if no photo was uploaded
+	avatarSrc := filepath.Join(template.Dir, DefaultAvatarFilename)
+	if _, err := os.Stat(avatarSrc); err != nil {
+		return "", nil
+	}
+	if err := utils.CopyFile(avatarSrc, filepath.Join(workDir, DefaultAvatarFilename)); err != nil {
+		return "", fmt.Errorf("failed to copy avatar: %w", err)
+	}
+	return DefaultAvatarFilename, nil
+}
+
+// staticPath returns the /static/ URL of a file under the working directory.
+func staticPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	return "/static/" + filepath.ToSlash(path)
+}
+
+func copySource(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return utils.CopyDir(src, dst)
+	}
+	return utils.CopyFile(src, dst)
+}
+
+// CopyPhoto normalizes the first photo in cv-photos/ to size pixels and
+// writes it to the template directory as avatar.png.
+func (g *CVGenerator) CopyPhoto(templateDir string, size int) error {
 	photoFiles, err := filepath.Glob("cv-photos/*")
 	if err != nil {
 		return fmt.Errorf("failed to find photos: %w", err)
@@ -188,74 +671,58 @@ func (cv *CVGenerator) CopyPhoto(templateDir string) error {
 	}
 
 	// #nosec G304 - sourcePhoto path is validated by caller
-	source, err := os.Open(sourcePhoto)
+	source, err := os.ReadFile(sourcePhoto)
 	if err != nil {
-		return fmt.Errorf("failed to open source photo: %w", err)
+		return fmt.Errorf("failed to read source photo: %w", err)
 	}
-	defer source.Close()
 
-	// #nosec G304 - destPhoto path is validated by caller
-	dest, err := os.Create(destPhoto)
+	// The template expects avatar.png, so the photo is always encoded as PNG
+	normalized, err := photo.Process(source, photo.Options{Size: size, PNG: true})
 	if err != nil {
-		return fmt.Errorf("failed to create destination photo: %w", err)
+		return fmt.Errorf("failed to process photo %s: %w", sourcePhoto, err)
 	}
-	defer dest.Close()
 
-	_, err = io.Copy(dest, source)
-	if err != nil {
-		return fmt.Errorf("failed to copy photo: %w", err)
+	if err := os.WriteFile(destPhoto, normalized.Data, 0o600); err != nil {
+		return fmt.Errorf("failed to write destination photo: %w", err)
 	}
 
 	fmt.Printf("Copied photo %s to %s\n", sourcePhoto, destPhoto)
 	return nil
 }
 
-func (cv *CVGenerator) HandlePhotoUploadToWorkDir(r *http.Request, workDir string) (string, error) {
+// readAvatar returns the photo uploaded in r, or nil if there is none.
+func readAvatar(r *http.Request) ([]byte, error) {
+	if r == nil {
+		return nil, nil
+	}
 	file, _, err := r.FormFile("avatar")
 	if err != nil {
 		// No file uploaded, that's okay
-		return "", nil
+		return nil, nil
 	}
 	defer file.Close()
 
-	// Read first few bytes to detect file format
-	header := make([]byte, 512)
-	n, err := file.Read(header)
+	avatar, err := io.ReadAll(file)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-
-	// Reset file pointer to beginning
-	if seeker, ok := file.(io.Seeker); ok {
-		_, err = seeker.Seek(0, 0)
-		if err != nil {
-			return "", err
-		}
+	if len(avatar) == 0 {
+		return nil, nil
 	}
+	return avatar, nil
+}
 
-	// Detect file format from header
-	var filename string
+// avatarFilename names a normalized photo after the format its header shows.
+func avatarFilename(avatar []byte) string {
 	switch {
-	case n >= 8 && header[0] == 0x89 && header[1] == 0x50 && header[2] == 0x4E && header[3] == 0x47:
+	case len(avatar) >= 8 && avatar[0] == 0x89 && avatar[1] == 0x50 && avatar[2] == 0x4E && avatar[3] == 0x47:
 		// PNG format
-		filename = DefaultAvatarFilename
-	case n >= 3 && header[0] == 0xFF && header[1] == 0xD8 && header[2] == 0xFF:
+		return DefaultAvatarFilename
+	case len(avatar) >= 3 && avatar[0] == 0xFF && avatar[1] == 0xD8 && avatar[2] == 0xFF:
 		// JPEG format
-		filename = "avatar.jpg"
+		return "avatar.jpg"
 	default:
 		// Default to PNG if format not recognized
-		filename = DefaultAvatarFilename
-	}
-
-	// Save uploaded file with detected extension
-	avatarPath := filepath.Join(workDir, filename)
-	// #nosec G304 - avatarPath is constructed from controlled workDir and filename
-	dest, err := os.Create(avatarPath)
-	if err != nil {
-		return "", err
+		return DefaultAvatarFilename
 	}
-	defer dest.Close()
-
-	_, err = io.Copy(dest, file)
-	return filename, err
 }
@@ -1,15 +1,26 @@
 package generator_test
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"image"
+	"image/jpeg"
+	"mime/multipart"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/AlexTLDR/mycv.quest/pkg/config"
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
 	"github.com/AlexTLDR/mycv.quest/pkg/generator"
+	"github.com/AlexTLDR/mycv.quest/pkg/photo"
+	"github.com/AlexTLDR/mycv.quest/pkg/renderer"
+	"github.com/AlexTLDR/mycv.quest/pkg/schema"
 )
 
 func TestNew(t *testing.T) {
@@ -90,16 +101,19 @@ func TestGetTemplateData(t *testing.T) {
 	cfg := &config.Config{
 		Templates: map[string]config.Template{
 			"basic": {
-				Name: "Basic Resume",
-				Dir:  basicDir,
+				Name:     "Basic Resume",
+				Dir:      basicDir,
+				Renderer: renderer.Basic{},
 			},
 			"modern": {
-				Name: "Modern Resume",
-				Dir:  modernDir,
+				Name:     "Modern Resume",
+				Dir:      modernDir,
+				Renderer: renderer.Modern{},
 			},
 			"vantage": {
-				Name: "Vantage Resume",
-				Dir:  vantageDir,
+				Name:     "Vantage Resume",
+				Dir:      vantageDir,
+				Renderer: renderer.Vantage{},
 			},
 		},
 	}
@@ -177,34 +191,181 @@ func TestGenerateFromFormInvalidTemplate(t *testing.T) {
 	}
 }
 
-func TestHandlePhotoUploadToWorkDir(t *testing.T) {
+func TestGenerateFromFormInvalidResumeUpload(t *testing.T) {
 	t.Parallel()
-	cfg := &config.Config{}
+	cfg := &config.Config{
+		Templates: map[string]config.Template{
+			"basic": {Name: "Basic Resume"},
+		},
+	}
+
+	gen := generator.New(cfg)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(generator.ResumeUploadField, "resume.json")
+	if err != nil {
+		t.Fatalf("Failed to create form file: %v", err)
+	}
+	part.Write([]byte("{not json"))
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/generate/basic", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	_, err = gen.GenerateFromForm("basic", req)
+	var validationErr *generator.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected a validation error, got: %v", err)
+	}
+	if validationErr.Errors[generator.ResumeUploadField] == "" {
+		t.Errorf("Expected the upload to be reported, got %v", validationErr.Errors)
+	}
+}
+
+func TestGenerateFromFormValidation(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{
+		Templates: map[string]config.Template{
+			"basic": {Name: "Basic Resume", Renderer: renderer.Basic{}, Fields: bundledFields("basic")},
+		},
+	}
+
 	gen := generator.New(cfg)
 
-	workDir := t.TempDir()
+	formData := url.Values{
+		"name":                      {"Jane Doe"},
+		"email":                     {"not-an-email"},
+		"accent_color":              {"red"},
+		"education[0][institution]": {"State University"},
+		"education[0][start_date]":  {"2020"},
+		"education[0][end_date]":    {"2018"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/generate/basic", strings.NewReader(formData.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	_, err := gen.GenerateFromForm("basic", req)
+	var validationErr *generator.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected a validation error, got: %v", err)
+	}
+	for _, name := range []string{"email", "accent_color", "education[0][end_date]"} {
+		if validationErr.Errors[name] == "" {
+			t.Errorf("Expected an error for %s, got %v", name, validationErr.Errors)
+		}
+	}
+	if validationErr.Values.Get("email") != "not-an-email" {
+		t.Error("Validation error should keep the submitted values")
+	}
+}
+
+func TestGenerateFromFormValidatesResumeUpload(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{
+		Templates: map[string]config.Template{
+			"basic": {Name: "Basic Resume", Renderer: renderer.Basic{}, Fields: bundledFields("basic")},
+		},
+	}
 
-	// Test with no file upload
-	emptyRequest := &http.Request{}
-	filename, err := gen.HandlePhotoUploadToWorkDir(emptyRequest, workDir)
+	gen := generator.New(cfg)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(generator.ResumeUploadField, "resume.json")
 	if err != nil {
-		t.Errorf("Expected no error with empty request, got: %v", err)
+		t.Fatalf("Failed to create form file: %v", err)
+	}
+	part.Write([]byte(`{"basics": {"email": "jane@example.com"}, "work": [{"position": "Engineer"}]}`))
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/generate/basic", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	_, err = gen.GenerateFromForm("basic", req)
+	var validationErr *generator.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected a validation error, got: %v", err)
 	}
-	if filename != "" {
-		t.Errorf("Expected empty filename with no upload, got: %s", filename)
+	if validationErr.Errors["name"] == "" {
+		t.Errorf("Expected the missing name to be reported, got %v", validationErr.Errors)
+	}
+	// The form is shown again with the imported data
+	if validationErr.Values.Get("work[0][title]") != "Engineer" || validationErr.Values.Get("email") != "jane@example.com" {
+		t.Errorf("Expected the imported values, got %v", validationErr.Values)
+	}
+}
+
+func TestPreviewFromFormCanceled(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{
+		Templates: map[string]config.Template{
+			"basic": {
+				Name:      "Basic Resume",
+				Dir:       "../../templates/basic/template",
+				InputFile: "main.typ",
+				Renderer:  renderer.Basic{},
+				Fields:    bundledFields("basic"),
+			},
+		},
 	}
 
-	// Note: Testing actual file upload would require creating a multipart request
-	// which is complex. The main logic is tested in the integration tests.
+	gen := generator.NewWithOptions(cfg, generator.Options{Compiler: &generator.FakeCompiler{}})
+
+	// Previews are not validated, so the invalid email does not stop it
+	formData := url.Values{"name": {"Jane Doe"}, "email": {"not-an-email"}}
+	req := httptest.NewRequest(http.MethodPost, "/preview/basic", strings.NewReader(formData.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := gen.PreviewFromForm(ctx, "basic", req)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a canceled preview, got: %v", err)
+	}
+
+	if _, err := gen.PreviewFromForm(ctx, "nonexistent", req); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected template not found error, got: %v", err)
+	}
 }
 
-func TestCopyPhoto(t *testing.T) {
+func TestDecodeForm(t *testing.T) {
 	t.Parallel()
+	cfg := &config.Config{
+		Templates: map[string]config.Template{
+			"vantage": {Name: "Vantage Resume", Renderer: renderer.Vantage{}, Fields: bundledFields("vantage")},
+		},
+	}
+
+	gen := generator.New(cfg)
+
+	formData := url.Values{
+		"name":              {"Jane Doe"},
+		"jobs[0][position]": {"Engineer"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/export/vantage", strings.NewReader(formData.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	data, err := gen.DecodeForm("vantage", req)
+	if err != nil {
+		t.Fatalf("DecodeForm failed: %v", err)
+	}
+	if data.Person.Name != "Jane Doe" || len(data.Experience) != 1 || data.Experience[0].Title != "Engineer" {
+		t.Errorf("Unexpected decoded CV: %+v", data)
+	}
+
+	if _, err := gen.DecodeForm("basic", req); err == nil {
+		t.Error("Expected error for unconfigured template")
+	}
+}
+
+func TestCopyPhoto(t *testing.T) {
+	// Not parallel: t.Chdir changes the working directory for the whole process.
 	cfg := &config.Config{}
 	gen := generator.New(cfg)
 
 	// Test with no photos directory
-	err := gen.CopyPhoto("/nonexistent")
+	err := gen.CopyPhoto("/nonexistent", 0)
 	if err == nil {
 		t.Error("Expected error when cv-photos directory doesn't exist")
 	}
@@ -229,20 +390,29 @@ func TestCopyPhoto(t *testing.T) {
 	}
 
 	// Test with no photos in directory
-	err = gen.CopyPhoto(templateDir)
+	err = gen.CopyPhoto(templateDir, 0)
 	if err == nil {
 		t.Error("Expected error when no photos found")
 	}
 
-	// Create a test photo
+	// Test with a file that is not an image
 	photoPath := filepath.Join(photosDir, "test.jpg")
 	err = os.WriteFile(photoPath, []byte("fake image data"), 0o600)
 	if err != nil {
 		t.Fatalf("Failed to create test photo: %v", err)
 	}
+	if err := gen.CopyPhoto(templateDir, 0); !errors.Is(err, photo.ErrUnsupported) {
+		t.Errorf("Expected the fake photo to be rejected, got: %v", err)
+	}
+
+	// Create a test photo
+	err = os.WriteFile(photoPath, encodeJPEG(t, 300, 200), 0o600)
+	if err != nil {
+		t.Fatalf("Failed to create test photo: %v", err)
+	}
 
 	// Test successful copy
-	err = gen.CopyPhoto(templateDir)
+	err = gen.CopyPhoto(templateDir, 100)
 	if err != nil {
 		t.Errorf("Expected successful copy, got error: %v", err)
 	}
@@ -260,8 +430,111 @@ func TestCopyPhoto(t *testing.T) {
 		t.Fatalf("Failed to read copied avatar: %v", err)
 	}
 
-	if string(content) != "fake image data" {
-		t.Error("Avatar content doesn't match source")
+	img, format, err := image.Decode(bytes.NewReader(content))
+	if err != nil || format != "png" {
+		t.Fatalf("Expected the avatar to be a PNG, got %q: %v", format, err)
+	}
+	if img.Bounds().Dx() != 100 || img.Bounds().Dy() != 100 {
+		t.Errorf("Expected a 100×100 avatar, got %v", img.Bounds())
+	}
+}
+
+// encodeJPEG returns a grey w×h JPEG image.
+func encodeJPEG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for i := range img.Pix {
+		img.Pix[i] = 0x80
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatalf("Failed to encode JPEG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestGenerateFromFormRejectsPhoto(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{
+		Templates: map[string]config.Template{
+			"modern": {Name: "Modern CV", NeedsPhoto: true, Renderer: renderer.Modern{}, Fields: bundledFields("modern")},
+		},
+	}
+
+	gen := generator.New(cfg)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for key, value := range map[string]string{"author": "Jane Doe", "job_title": "Engineer", "email": "jane@example.com"} {
+		writer.WriteField(key, value)
+	}
+	part, err := writer.CreateFormFile("avatar", "photo.png")
+	if err != nil {
+		t.Fatalf("Failed to create form file: %v", err)
+	}
+	part.Write([]byte("\x89PNG\r\n\x1a\nfake png data"))
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/generate/modern", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	_, err = gen.ReadForm("modern", req)
+	var validationErr *generator.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected a validation error, got: %v", err)
+	}
+	if !strings.Contains(validationErr.Errors["avatar"], "PNG, JPEG or WebP") {
+		t.Errorf("Expected the photo to be reported, got %v", validationErr.Errors)
+	}
+	if validationErr.Values.Get("author") != "Jane Doe" {
+		t.Error("Validation error should keep the submitted values")
+	}
+}
+
+func TestPreviewFromFormReusesPhoto(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{
+		Templates: map[string]config.Template{
+			"modern": {
+				Name:       "Modern CV",
+				Dir:        "../../templates/modern/template",
+				InputFile:  "cv.typ",
+				NeedsPhoto: true,
+				Renderer:   renderer.Modern{},
+				Fields:     bundledFields("modern"),
+			},
+		},
+		WorkDir: t.TempDir(),
+	}
+	compiler := &generator.FakeCompiler{}
+	gen := generator.NewWithOptions(cfg, generator.Options{Compiler: compiler})
+	avatar := encodeJPEG(t, 300, 200)
+
+	// Each keystroke previews the form again, uploading the same photo
+	for _, name := range []string{"J", "Ja", "Jane"} {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		writer.WriteField("author", name)
+		part, err := writer.CreateFormFile("avatar", "photo.jpg")
+		if err != nil {
+			t.Fatalf("Failed to create form file: %v", err)
+		}
+		part.Write(avatar)
+		writer.Close()
+
+		req := httptest.NewRequest(http.MethodPost, "/preview/modern", &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		if _, err := gen.PreviewFromForm(context.Background(), "modern", req); err != nil {
+			t.Fatalf("PreviewFromForm failed: %v", err)
+		}
+	}
+
+	// The photo is processed on the scheduler once, then each preview compiles
+	if jobs := len(compiler.Jobs()); jobs != 3 {
+		t.Errorf("Expected a compilation per preview, got %d", jobs)
+	}
+	if completed := gen.Scheduler().Stats().Completed; completed != 4 {
+		t.Errorf("Expected one photo job and three compilations, got %d jobs", completed)
 	}
 }
 
@@ -301,28 +574,474 @@ func TestGenerate(t *testing.T) {
 		OutputDir: outputDir,
 	}
 
-	gen := generator.New(cfg)
+	compiler := &generator.FakeCompiler{}
+	gen := generator.NewWithOptions(cfg, generator.Options{Compiler: compiler})
 
 	// Test invalid template
-	err = gen.Generate(context.Background(), "nonexistent")
+	err = gen.Generate(context.Background(), "nonexistent", generator.Output{})
 	if err == nil {
 		t.Error("Expected error for nonexistent template")
 	}
 
-	// Test valid template (this will fail if typst is not installed)
-	// In a real CI environment, you might want to skip this test or mock the typst command
-	err = gen.Generate(context.Background(), "basic")
+	err = gen.Generate(context.Background(), "basic", generator.Output{})
 	if err != nil {
-		// If typst is not installed, skip this test
-		if strings.Contains(err.Error(), "executable file not found") {
-			t.Skip("typst not installed, skipping compilation test")
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	// Verify output file was created
+	outputFile := filepath.Join(outputDir, "cv-basic.pdf")
+	if pdfData, err := os.ReadFile(outputFile); err != nil || !bytes.Equal(pdfData, generator.StubPDF) {
+		t.Errorf("Output PDF was not written: %v", err)
+	}
+
+	// The unchanged template is served from the cache
+	if err := gen.Generate(context.Background(), "basic", generator.Output{}); err != nil {
+		t.Fatalf("Second Generate failed: %v", err)
+	}
+	if jobs := compiler.Jobs(); len(jobs) != 1 || jobs[0].Dir != templateDir {
+		t.Errorf("Expected one compilation in the template directory, got %+v", jobs)
+	}
+}
+
+func TestGenerateFromCVCache(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{
+		Templates: map[string]config.Template{
+			"basic": {
+				Name:      "Basic Resume",
+				Dir:       "../../templates/basic/template",
+				InputFile: "main.typ",
+				Renderer:  renderer.Basic{},
+			},
+		},
+		WorkDir: t.TempDir(),
+	}
+
+	compiler := &generator.FakeCompiler{}
+	gen := generator.NewWithOptions(cfg, generator.Options{Compiler: compiler})
+	ctx := context.Background()
+
+	for _, name := range []string{"Jane Doe", "Jane Doe", "John Doe", "Jane Doe"} {
+		if _, err := gen.GenerateFromCV(ctx, "basic", &cv.CV{Person: cv.Person{Name: name}}); err != nil {
+			t.Fatalf("GenerateFromCV failed: %v", err)
+		}
+	}
+
+	if jobs := compiler.Jobs(); len(jobs) != 2 {
+		t.Errorf("Expected one compilation per distinct CV, got %d", len(jobs))
+	}
+	if stats := gen.Cache().Stats(); stats.Hits != 2 || stats.Misses != 2 || stats.Entries != 2 {
+		t.Errorf("Unexpected cache stats: %+v", stats)
+	}
+}
+
+func TestGenerateFromCVCacheFollowsSharedDirs(t *testing.T) {
+	t.Parallel()
+	fontDir, packageDir := t.TempDir(), t.TempDir()
+	cfg := &config.Config{
+		Templates: map[string]config.Template{
+			"basic": {
+				Name:      "Basic Resume",
+				Dir:       "../../templates/basic/template",
+				InputFile: "main.typ",
+				Renderer:  renderer.Basic{},
+			},
+		},
+		WorkDir:    t.TempDir(),
+		FontDir:    fontDir,
+		PackageDir: packageDir,
+	}
+
+	// The shared directories are fingerprinted once, so each change is
+	// picked up by a restarted generator sharing the cache
+	compiler := &generator.FakeCompiler{}
+	cache := generator.NewRenderCache(generator.DefaultCacheSize)
+	generate := func() {
+		t.Helper()
+		gen := generator.NewWithOptions(cfg, generator.Options{Compiler: compiler, Cache: cache})
+		if _, err := gen.GenerateFromCV(context.Background(), "basic", &cv.CV{Person: cv.Person{Name: "Jane Doe"}}); err != nil {
+			t.Fatalf("GenerateFromCV failed: %v", err)
 		}
-		t.Errorf("Generate failed: %v", err)
-	} else {
-		// Verify output file was created
-		outputFile := filepath.Join(outputDir, "cv-basic.pdf")
-		if _, err := os.Stat(outputFile); os.IsNotExist(err) {
-			t.Error("Output PDF was not created")
+	}
+
+	generate()
+	generate()
+	// A new font or an updated package makes Typst's output differ
+	if err := os.WriteFile(filepath.Join(fontDir, "Roboto-Regular.ttf"), []byte("font"), 0o600); err != nil {
+		t.Fatalf("Failed to write font: %v", err)
+	}
+	generate()
+	if err := os.MkdirAll(filepath.Join(packageDir, "preview", "pkg", "0.1.0"), 0o750); err != nil {
+		t.Fatalf("Failed to create package: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(packageDir, "preview", "pkg", "0.1.0", "lib.typ"), []byte("#let x = 1"), 0o600); err != nil {
+		t.Fatalf("Failed to write package: %v", err)
+	}
+	generate()
+
+	if jobs := compiler.Jobs(); len(jobs) != 3 {
+		t.Errorf("Expected a compilation after each change, got %d", len(jobs))
+	}
+}
+
+func TestGenerateFromCVCacheFollowsLinkedPackages(t *testing.T) {
+	t.Parallel()
+	packageDir, source := t.TempDir(), t.TempDir()
+	if err := os.MkdirAll(filepath.Join(packageDir, "local", "pkg"), 0o750); err != nil {
+		t.Fatalf("Failed to create package: %v", err)
+	}
+	// Packages are linked into the package directory, as the bundled ones are
+	if err := os.Symlink(source, filepath.Join(packageDir, "local", "pkg", "0.1.0")); err != nil {
+		t.Fatalf("Failed to link package: %v", err)
+	}
+	writePackage := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(source, "lib.typ"), []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write package: %v", err)
 		}
 	}
+	cfg := &config.Config{
+		Templates: map[string]config.Template{
+			"basic": {
+				Name:      "Basic Resume",
+				Dir:       "../../templates/basic/template",
+				InputFile: "main.typ",
+				Renderer:  renderer.Basic{},
+			},
+		},
+		WorkDir:    t.TempDir(),
+		PackageDir: packageDir,
+	}
+
+	compiler := &generator.FakeCompiler{}
+	cache := generator.NewRenderCache(generator.DefaultCacheSize)
+	generate := func() {
+		t.Helper()
+		gen := generator.NewWithOptions(cfg, generator.Options{Compiler: compiler, Cache: cache})
+		if _, err := gen.GenerateFromCV(context.Background(), "basic", &cv.CV{Person: cv.Person{Name: "Jane Doe"}}); err != nil {
+			t.Fatalf("GenerateFromCV failed: %v", err)
+		}
+	}
+
+	writePackage("#let x = 1")
+	generate()
+	generate()
+	writePackage("#let x = 12")
+	generate()
+
+	if jobs := compiler.Jobs(); len(jobs) != 2 {
+		t.Errorf("Expected a compilation after the linked package changed, got %d", len(jobs))
+	}
+}
+
+func TestGenerateFromCVSortsEntries(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{
+		Templates: map[string]config.Template{
+			"basic": {Name: "Basic Resume", Dir: "../../templates/basic/template", InputFile: "cv.typ", Renderer: renderer.Basic{}},
+		},
+		WorkDir: t.TempDir(),
+	}
+	compiler := &generator.FakeCompiler{}
+	gen := generator.NewWithOptions(cfg, generator.Options{Compiler: compiler})
+
+	data := &cv.CV{Experience: []cv.Experience{
+		{Title: "Intern", StartDate: cv.MustParseDate("2019")},
+		{Title: "Engineer", StartDate: cv.MustParseDate("2020"), EndDate: cv.Present},
+	}}
+	if _, err := gen.GenerateFromCV(context.Background(), "basic", data); err != nil {
+		t.Fatalf("GenerateFromCV failed: %v", err)
+	}
+
+	source := string(compiler.Jobs()[0].Sources[renderer.ViewFile])
+	if strings.Index(source, "Engineer") > strings.Index(source, "Intern") {
+		t.Errorf("Expected the newest entry first:\n%s", source)
+	}
+	if data.Experience[0].Title != "Intern" {
+		t.Error("The caller's CV should keep its order")
+	}
+}
+
+func TestGenerateFromCVCompileError(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{
+		Templates: map[string]config.Template{
+			"basic": {Name: "Basic Resume", Dir: "../../templates/basic/template", InputFile: "cv.typ", Renderer: renderer.Basic{}},
+		},
+		WorkDir: t.TempDir(),
+	}
+
+	compileErr := &generator.CompileError{Err: errors.New("exit status 1"), Output: "error: unknown variable"}
+	gen := generator.NewWithOptions(cfg, generator.Options{Compiler: &generator.FakeCompiler{Err: compileErr}})
+
+	_, err := gen.GenerateFromCV(context.Background(), "basic", &cv.CV{})
+	var got *generator.CompileError
+	if !errors.As(err, &got) || got.Output != "error: unknown variable" {
+		t.Errorf("Expected the compile error, got: %v", err)
+	}
+	// Failures are not cached
+	if stats := gen.Cache().Stats(); stats.Entries != 0 {
+		t.Errorf("Expected an empty cache, got %+v", stats)
+	}
+}
+
+func TestGenerateFromFormBasic(t *testing.T) {
+	t.Parallel()
+	// Create test configuration
+	cfg := &config.Config{
+		Templates: map[string]config.Template{
+			"basic": {
+				Name:      "Basic Resume",
+				Dir:       "../../templates/basic/template",
+				InputFile: "cv.typ",
+				Renderer:  renderer.Basic{},
+				Fields:    bundledFields("basic"),
+			},
+		},
+		OutputDir: "test_output",
+	}
+
+	compiler := &generator.FakeCompiler{}
+	gen := generator.NewWithOptions(cfg, generator.Options{Compiler: compiler})
+
+	// Create test form data
+	formData := url.Values{
+		"name":                      {"John Doe"},
+		"location":                  {"New York, NY"},
+		"email":                     {"john.doe@example.com"},
+		"github":                    {"johndoe"},
+		"linkedin":                  {"johndoe"},
+		"phone":                     {"+1-555-123-4567"},
+		"personal_site":             {"https://johndoe.dev"},
+		"accent_color":              {"#26428b"},
+		"education[0][institution]": {"University of Technology"},
+		"education[0][location]":    {"Boston, MA"},
+		"education[0][start_date]":  {"2018"},
+		"education[0][end_date]":    {"2022"},
+		"education[0][degree]":      {"Bachelor of Science in Computer Science"},
+		"education[0][details]":     {"Graduated Magna Cum Laude\nRelevant Coursework: Data Structures, Algorithms"},
+		"work[0][title]":            {"Software Engineer"},
+		"work[0][company]":          {"Tech Corp"},
+		"work[0][location]":         {"San Francisco, CA"},
+		"work[0][start_date]":       {"2022"},
+		"work[0][end_date]":         {"Present"},
+		"work[0][description]":      {"Developed web applications using Go and React\nImplemented REST APIs"},
+		"projects[0][name]":         {"Portfolio Website"},
+		"projects[0][role]":         {"Full Stack Developer"},
+		"projects[0][start_date]":   {"2021"},
+		"projects[0][end_date]":     {"2022"},
+		"projects[0][url]":          {"https://github.com/johndoe/portfolio"},
+		"projects[0][description]":  {"Built responsive portfolio website\nUsed modern web technologies"},
+		"programming_languages":     {"Go, JavaScript, Python"},
+		"technologies":              {"React, Node.js, Docker"},
+	}
+
+	// Create HTTP request with form data
+	req := httptest.NewRequest(http.MethodPost, "/generate/basic", strings.NewReader(formData.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	// Generate CV
+	pdfData, err := gen.GenerateFromForm("basic", req)
+	if err != nil {
+		t.Fatalf("Failed to generate basic CV: %v", err)
+	}
+
+	// Verify PDF data is not empty
+	if len(pdfData) == 0 {
+		t.Fatal("Generated PDF data is empty")
+	}
+
+	// Verify PDF header (PDF files start with %PDF-)
+	if !strings.HasPrefix(string(pdfData[:4]), "%PDF") {
+		t.Fatal("Generated data is not a valid PDF file")
+	}
+
+	// The compiler saw the rendered CV in the work directory
+	jobs := compiler.Jobs()
+	if len(jobs) != 1 || !strings.Contains(string(jobs[0].Sources[renderer.ViewFile]), "John Doe") {
+		t.Errorf("Expected the data file with the submitted name among the compiled sources, got %d jobs", len(jobs))
+	}
+}
+
+func TestGenerateFromFormModern(t *testing.T) {
+	t.Parallel()
+	// Create test configuration
+	cfg := &config.Config{
+		Templates: map[string]config.Template{
+			"modern": {
+				Name:       "Modern Resume",
+				Dir:        "../../templates/modern/template",
+				InputFile:  "cv.typ",
+				NeedsPhoto: true,
+				Renderer:   renderer.Modern{},
+				Fields:     bundledFields("modern"),
+			},
+		},
+		OutputDir: "test_output",
+	}
+
+	compiler := &generator.FakeCompiler{}
+	gen := generator.NewWithOptions(cfg, generator.Options{Compiler: compiler})
+
+	// Create test form data
+	formData := url.Values{
+		"author":                         {"Alice Johnson"},
+		"job_title":                      {"Senior Software Engineer"},
+		"bio":                            {"Passionate developer with 5+ years of experience"},
+		"email":                          {"alice.johnson@example.com"},
+		"mobile":                         {"+1-555-444-3333"},
+		"location":                       {"Seattle, WA"},
+		"linkedin":                       {"alicejohnson"},
+		"github":                         {"alicejohnson"},
+		"website":                        {"https://alicejohnson.dev"},
+		"education[0][title]":            {"Master of Computer Science"},
+		"education[0][subtitle]":         {"University of Washington"},
+		"education[0][date_from]":        {"2018"},
+		"education[0][date_to]":          {"2020"},
+		"education[0][task_description]": {"Specialized in Machine Learning\nThesis on Neural Networks"},
+		"work[0][title]":                 {"Senior Software Engineer"},
+		"work[0][subtitle]":              {"Tech Innovations Inc."},
+		"work[0][facility_description]":  {"Leading technology company"},
+		"work[0][date_from]":             {"2020"},
+		"work[0][date_to]":               {"Present"},
+		"work[0][task_description]":      {"Lead development of cloud platforms\nManage team of 5 engineers"},
+		"skills":                         {"Go, Rust, Kubernetes, AWS, Machine Learning"},
+		"projects[0][title]":             {"Cloud Migration Platform"},
+		"projects[0][subtitle]":          {"Internal Tool"},
+		"projects[0][date_from]":         {"2023"},
+		"projects[0][date_to]":           {"2024"},
+		"projects[0][description]":       {"Automated cloud migration for legacy systems"},
+		"certificates[0][title]":         {"AWS Solutions Architect"},
+		"certificates[0][subtitle]":      {"Amazon Web Services"},
+		"certificates[0][date_from]":     {"2022"},
+		"certificates[0][date_to]":       {"2025"},
+		"languages":                      {"English, Spanish, French"},
+		"interests":                      {"Rock climbing, Photography, Open source"},
+	}
+
+	// Create HTTP request with form data
+	req := httptest.NewRequest(http.MethodPost, "/generate/modern", strings.NewReader(formData.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	// Generate CV
+	pdfData, err := gen.GenerateFromForm("modern", req)
+	if err != nil {
+		t.Fatalf("Failed to generate modern CV: %v", err)
+	}
+
+	// Verify PDF data is not empty
+	if len(pdfData) == 0 {
+		t.Fatal("Generated PDF data is empty")
+	}
+
+	// Verify PDF header (PDF files start with %PDF-)
+	if !strings.HasPrefix(string(pdfData[:4]), "%PDF") {
+		t.Fatal("Generated data is not a valid PDF file")
+	}
+
+	// The compiler saw the rendered CV in the work directory
+	jobs := compiler.Jobs()
+	if len(jobs) != 1 || !strings.Contains(string(jobs[0].Sources[renderer.ViewFile]), "Alice Johnson") {
+		t.Errorf("Expected the data file with the submitted name among the compiled sources, got %d jobs", len(jobs))
+	}
+}
+
+func TestGenerateFromFormVantage(t *testing.T) {
+	t.Parallel()
+	// Create test configuration
+	cfg := &config.Config{
+		Templates: map[string]config.Template{
+			"vantage": {
+				Name:      "Vantage Resume",
+				Dir:       "../../templates/vantage",
+				InputFile: "example.typ",
+				Renderer:  renderer.Vantage{},
+				Fields:    bundledFields("vantage"),
+			},
+		},
+		OutputDir: "test_output",
+	}
+
+	compiler := &generator.FakeCompiler{}
+	gen := generator.NewWithOptions(cfg, generator.Options{Compiler: compiler})
+
+	// Create test form data
+	formData := url.Values{
+		"name":                  {"Charlie Brown"},
+		"title":                 {"Full Stack Developer"},
+		"email":                 {"charlie.brown@example.com"},
+		"address":               {"123 Main St"},
+		"location":              {"Portland, OR"},
+		"linkedin_url":          {"https://linkedin.com/in/charliebrown"},
+		"linkedin_display_text": {"linkedin.com/in/charliebrown"},
+		"github_url":            {"https://github.com/charliebrown"},
+		"github_display_text":   {"github.com/charliebrown"},
+		"website_url":           {"https://charliebrown.dev"},
+		"website_display_text":  {"charliebrown.dev"},
+		"position":              {"Senior Developer"},
+		"tagline":               {"Building scalable web applications"},
+		"objective":             {"Seeking challenging opportunities in full-stack development"},
+		"jobs[0][position]":     {"Software Engineer"},
+		"jobs[0][company_name]": {"Acme Corp"},
+		"jobs[0][company_link]": {"https://acme.com"},
+		"jobs[0][product_name]": {"E-commerce Platform"},
+		"jobs[0][product_link]": {"https://acme.com/platform"},
+		"jobs[0][from]":         {"2022"},
+		"jobs[0][to]":           {"Present"},
+		"jobs[0][location]":     {"Remote"},
+		"jobs[0][description]":  {"Developed microservices architecture\nImplemented REST APIs\nOptimized database performance"},
+
+		"education[0][place_name]":      {"Oregon State University"},
+		"education[0][place_link]":      {"https://oregonstate.edu"},
+		"education[0][degree]":          {"Bachelor of Science"},
+		"education[0][major]":           {"Computer Science"},
+		"education[0][track]":           {"Software Engineering"},
+		"education[0][from]":            {"2018"},
+		"education[0][to]":              {"2022"},
+		"education[0][location]":        {"Corvallis, OR"},
+		"technical_expertise[0][name]":  {"Go"},
+		"technical_expertise[0][level]": {"5"},
+		"technical_expertise[1][name]":  {"React"},
+		"technical_expertise[1][level]": {"4"},
+		"achievements[0][name]":         {"Employee of the Year"},
+		"achievements[0][description]":  {"Recognized for outstanding contribution to platform development"},
+		"skills":                        {"Go, React, PostgreSQL, Docker"},
+		"methodology":                   {"Agile, Scrum, TDD"},
+		"tools":                         {"Git, Docker, Kubernetes, AWS"},
+	}
+
+	// Create HTTP request with form data
+	req := httptest.NewRequest(http.MethodPost, "/generate/vantage", strings.NewReader(formData.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	// Generate CV
+	pdfData, err := gen.GenerateFromForm("vantage", req)
+	if err != nil {
+		t.Fatalf("Failed to generate vantage CV: %v", err)
+	}
+
+	// Verify PDF data is not empty
+	if len(pdfData) == 0 {
+		t.Fatal("Generated PDF data is empty")
+	}
+
+	// Verify PDF header (PDF files start with %PDF-)
+	if !strings.HasPrefix(string(pdfData[:4]), "%PDF") {
+		t.Fatal("Generated data is not a valid PDF file")
+	}
+
+	// The compiler saw the rendered CV in the work directory
+	jobs := compiler.Jobs()
+	if len(jobs) != 1 || !strings.Contains(string(jobs[0].Sources["configuration.yaml"]), "Charlie Brown") {
+		t.Errorf("Expected configuration.yaml with the submitted name among the compiled sources, got %d jobs", len(jobs))
+	}
+}
+
+// bundledFields returns the field schema from a bundled template's manifest.
+func bundledFields(key string) []schema.Field {
+	manifest, err := config.ReadManifest(filepath.Join("../../templates", key, config.ManifestFile))
+	if err != nil {
+		panic(err)
+	}
+	return manifest.Fields
 }
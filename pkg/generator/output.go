@@ -0,0 +1,133 @@
+package generator
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Format is a file format Typst compiles a CV to.
+type Format string
+
+const (
+	FormatPDF Format = "pdf"
+	FormatPNG Format = "png"
+	FormatSVG Format = "svg"
+)
+
+// DPI bounds of PNG pages. Typst calls the resolution pixels per inch.
+const (
+	DefaultDPI = 144
+	MaxDPI     = 600
+)
+
+// ErrPageNotFound is returned for an image of a page the CV does not have.
+var ErrPageNotFound = errors.New("page not found")
+
+// ParseFormat returns the format named by s, as given to -format.
+func ParseFormat(s string) (Format, error) {
+	switch format := Format(s); format {
+	case FormatPDF, FormatPNG, FormatSVG:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown format %q (want pdf, png or svg)", s)
+	}
+}
+
+// Output selects what a compilation produces. The zero value is the PDF.
+type Output struct {
+	Format Format
+	// DPI is the resolution of PNG pages. Zero uses DefaultDPI.
+	DPI int
+	// Page is the 1-based page to render as an image. Zero renders every
+	// page, as a zip archive of page-1.png, page-2.png and so on.
+	Page int
+}
+
+// IsImage reports whether the output is page images rather than a PDF.
+func (o Output) IsImage() bool {
+	return o.Format == FormatPNG || o.Format == FormatSVG
+}
+
+// Extension returns the extension of the output's file, without the dot.
+func (o Output) Extension() string {
+	switch {
+	case !o.IsImage():
+		return string(FormatPDF)
+	case o.Page == 0:
+		return "zip"
+	default:
+		return string(o.Format)
+	}
+}
+
+// ContentType returns the MIME type of the output's file.
+func (o Output) ContentType() string {
+	switch o.Extension() {
+	case "png":
+		return "image/png"
+	case "svg":
+		return "image/svg+xml"
+	case "zip":
+		return "application/zip"
+	default:
+		return "application/pdf"
+	}
+}
+
+// Validate checks the output's format, resolution and page.
+func (o Output) Validate() error {
+	switch {
+	case o.Format != "" && o.Format != FormatPDF && !o.IsImage():
+		return fmt.Errorf("unknown format %q", o.Format)
+	case o.DPI < 0 || o.DPI > MaxDPI:
+		return fmt.Errorf("dpi must be between 1 and %d", MaxDPI)
+	case o.Page < 0:
+		return fmt.Errorf("page must be positive")
+	case o.Page > 0 && !o.IsImage():
+		return fmt.Errorf("pages can only be selected for images")
+	default:
+		return nil
+	}
+}
+
+// dpi returns the resolution PNG pages are rendered at.
+func (o Output) dpi() int {
+	if o.DPI == 0 {
+		return DefaultDPI
+	}
+	return o.DPI
+}
+
+// String identifies the output in cache keys, e.g. "png@144/2".
+func (o Output) String() string {
+	if !o.IsImage() {
+		return string(FormatPDF)
+	}
+	s := string(o.Format)
+	if o.Format == FormatPNG {
+		s += "@" + strconv.Itoa(o.dpi())
+	}
+	return s + "/" + strconv.Itoa(o.Page)
+}
+
+// zipPages archives page images as page-1.<ext>, page-2.<ext> and so on.
+func zipPages(pages [][]byte, ext string) ([]byte, error) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for i, page := range pages {
+		w, err := archive.Create(fmt.Sprintf("page-%d.%s", i+1, ext))
+		if err != nil {
+			return nil, fmt.Errorf("failed to archive page %d: %w", i+1, err)
+		}
+		if _, err := w.Write(page); err != nil {
+			return nil, fmt.Errorf("failed to archive page %d: %w", i+1, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to archive pages: %w", err)
+	}
+	return buf.Bytes(), nil
+}
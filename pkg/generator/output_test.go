@@ -0,0 +1,105 @@
+package generator_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/config"
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+	"github.com/AlexTLDR/mycv.quest/pkg/generator"
+	"github.com/AlexTLDR/mycv.quest/pkg/renderer"
+)
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+	for _, name := range []string{"pdf", "png", "svg"} {
+		if format, err := generator.ParseFormat(name); err != nil || string(format) != name {
+			t.Errorf("ParseFormat(%q) = %q, %v", name, format, err)
+		}
+	}
+	if _, err := generator.ParseFormat("docx"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
+
+func TestOutputValidate(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		output    generator.Output
+		extension string
+		valid     bool
+	}{
+		{generator.Output{}, "pdf", true},
+		{generator.Output{Format: generator.FormatPNG, Page: 2}, "png", true},
+		{generator.Output{Format: generator.FormatSVG}, "zip", true},
+		{generator.Output{Format: generator.FormatPNG, DPI: generator.MaxDPI + 1}, "zip", false},
+		{generator.Output{Format: generator.FormatPNG, Page: -1}, "png", false},
+		{generator.Output{Format: generator.FormatPDF, Page: 1}, "pdf", false},
+		{generator.Output{Format: "gif"}, "pdf", false},
+	}
+
+	for _, tc := range testCases {
+		if err := tc.output.Validate(); (err == nil) != tc.valid {
+			t.Errorf("%+v: Validate() = %v, want valid %t", tc.output, err, tc.valid)
+		}
+		if got := tc.output.Extension(); got != tc.extension {
+			t.Errorf("%+v: Extension() = %q, want %q", tc.output, got, tc.extension)
+		}
+	}
+}
+
+func TestGenerateImages(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{
+		Templates: map[string]config.Template{
+			"basic": {Name: "Basic Resume", Dir: "../../templates/basic/template", InputFile: "cv.typ", Renderer: renderer.Basic{}},
+		},
+		WorkDir: t.TempDir(),
+	}
+	compiler := &generator.FakeCompiler{Pages: 2}
+	gen := generator.NewWithOptions(cfg, generator.Options{Compiler: compiler})
+	submission := &generator.Submission{CV: &cv.CV{}}
+	ctx := context.Background()
+
+	page, err := gen.GenerateFromSubmission(ctx, "basic", submission, generator.Output{Format: generator.FormatPNG, DPI: 300, Page: 2})
+	if err != nil || !bytes.Equal(page, generator.StubPNG) {
+		t.Fatalf("Expected the second page as PNG, got %q, %v", page, err)
+	}
+	if job := compiler.Jobs()[0]; job.Output.DPI != 300 || job.Output.Page != 2 {
+		t.Errorf("Expected the compiler to get the resolution and page, got %+v", job.Output)
+	}
+
+	if _, err := gen.GenerateFromSubmission(ctx, "basic", submission, generator.Output{Format: generator.FormatPNG, Page: 3}); !errors.Is(err, generator.ErrPageNotFound) {
+		t.Errorf("Expected ErrPageNotFound, got %v", err)
+	}
+
+	archive, err := gen.GenerateFromSubmission(ctx, "basic", submission, generator.Output{Format: generator.FormatSVG})
+	if err != nil {
+		t.Fatalf("GenerateFromSubmission failed: %v", err)
+	}
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatalf("Expected a zip archive: %v", err)
+	}
+	if len(reader.File) != 2 || reader.File[0].Name != "page-1.svg" || reader.File[1].Name != "page-2.svg" {
+		t.Fatalf("Expected page-1.svg and page-2.svg, got %d files", len(reader.File))
+	}
+	file, err := reader.File[1].Open()
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer file.Close()
+	if content, _ := io.ReadAll(file); !bytes.Equal(content, generator.StubSVG) {
+		t.Errorf("page-2.svg = %q, want the stub SVG", content)
+	}
+
+	// The PDF of the same CV is cached apart from its images
+	pdf, err := gen.GenerateFromSubmission(ctx, "basic", submission, generator.Output{})
+	if err != nil || !bytes.Equal(pdf, generator.StubPDF) {
+		t.Errorf("Expected the stub PDF, got %q, %v", pdf, err)
+	}
+}
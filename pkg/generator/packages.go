@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/config"
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+	"github.com/AlexTLDR/mycv.quest/pkg/typst"
+)
+
+// MissingPackages returns the Typst packages the templates import that are
+// not in the package directory, so compiling would need the network.
+// Imports of the rendered CV are found by rendering an empty one.
+func (g *CVGenerator) MissingPackages() ([]typst.Package, error) {
+	var sources [][]byte
+	for key, template := range g.config.Templates {
+		templateSources, err := typstSources(template)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template %s: %w", key, err)
+		}
+		sources = append(sources, templateSources...)
+	}
+	return typst.Missing(g.config.PackageDir, sources)
+}
+
+// VendorPackages fetches the missing packages from registry into the package
+// directory, along with the packages they import, and returns what it fetched.
+func (g *CVGenerator) VendorPackages(ctx context.Context, registry string) ([]typst.Package, error) {
+	var fetched []typst.Package
+	for {
+		missing, err := g.MissingPackages()
+		if err != nil || len(missing) == 0 {
+			return fetched, err
+		}
+		for _, pkg := range missing {
+			if err := typst.Fetch(ctx, http.DefaultClient, registry, g.config.PackageDir, pkg); err != nil {
+				return fetched, err
+			}
+			fetched = append(fetched, pkg)
+			g.resetSharedFingerprint()
+		}
+	}
+}
+
+// typstSources returns the Typst files copied into a template's work
+// directory and those its renderer generates.
+func typstSources(template config.Template) ([][]byte, error) {
+	var sources [][]byte
+	for _, file := range append([]string{template.InputFile}, template.Assets...) {
+		err := filepath.WalkDir(filepath.Join(template.Dir, file), func(path string, entry os.DirEntry, err error) error {
+			if err != nil || entry.IsDir() || filepath.Ext(path) != ".typ" {
+				return err
+			}
+			// #nosec G304 - path is inside the template directory
+			source, err := os.ReadFile(path)
+			sources = append(sources, source)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if template.Renderer != nil {
+		files, err := template.Renderer.Render(&cv.CV{}, "")
+		if err != nil {
+			return nil, err
+		}
+		for name, content := range files {
+			if filepath.Ext(name) == ".typ" {
+				sources = append(sources, content)
+			}
+		}
+	}
+	return sources, nil
+}
@@ -0,0 +1,75 @@
+package generator_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/config"
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+	"github.com/AlexTLDR/mycv.quest/pkg/generator"
+	"github.com/AlexTLDR/mycv.quest/pkg/renderer"
+)
+
+func TestMissingPackages(t *testing.T) {
+	t.Parallel()
+	packageDir := t.TempDir()
+	cfg := &config.Config{
+		Templates: map[string]config.Template{
+			"basic": {Name: "Basic Resume", Dir: "../../templates/basic/template", InputFile: "cv.typ", Renderer: renderer.Basic{}},
+		},
+		PackageDir: packageDir,
+	}
+	gen := generator.New(cfg)
+
+	missing, err := gen.MissingPackages()
+	if err != nil {
+		t.Fatalf("MissingPackages failed: %v", err)
+	}
+	if len(missing) != 1 || missing[0].String() != "@local/basic-resume:0.2.9" {
+		t.Fatalf("Expected the basic-resume package to be missing, got %v", missing)
+	}
+
+	// Vendored, the package's own imports are checked
+	dir := missing[0].Dir(packageDir)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		t.Fatalf("Failed to create package dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "typst.toml"), []byte("[package]\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write typst.toml: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "lib.typ"), []byte(`#import "@preview/scienceicons:0.1.0": orcid-icon`), 0o600); err != nil {
+		t.Fatalf("Failed to write lib.typ: %v", err)
+	}
+	missing, err = gen.MissingPackages()
+	if err != nil {
+		t.Fatalf("MissingPackages failed: %v", err)
+	}
+	if len(missing) != 1 || missing[0].Name != "scienceicons" {
+		t.Errorf("Expected only scienceicons to be missing, got %v", missing)
+	}
+}
+
+func TestCompileUsesPackageAndFontDirs(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{
+		Templates: map[string]config.Template{
+			"basic": {Name: "Basic Resume", Dir: "../../templates/basic/template", InputFile: "cv.typ", Renderer: renderer.Basic{}},
+		},
+		WorkDir:    t.TempDir(),
+		PackageDir: "packages",
+		FontDir:    "fonts",
+	}
+	compiler := &generator.FakeCompiler{}
+	gen := generator.NewWithOptions(cfg, generator.Options{Compiler: compiler})
+
+	if _, err := gen.GenerateFromCV(context.Background(), "basic", &cv.CV{}); err != nil {
+		t.Fatalf("GenerateFromCV failed: %v", err)
+	}
+	packages, _ := filepath.Abs("packages")
+	fonts, _ := filepath.Abs("fonts")
+	if jobs := compiler.Jobs(); len(jobs) != 1 || jobs[0].PackagePath != packages || jobs[0].FontPath != fonts {
+		t.Errorf("Expected compilation against %s and %s, got %+v", packages, fonts, jobs)
+	}
+}
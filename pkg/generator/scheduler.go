@@ -0,0 +1,177 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// ErrQueueFull is returned when every compile worker is busy and the queue
+// has no room for another job.
+var ErrQueueFull = errors.New("compile queue is full")
+
+// SchedulerConfig bounds the Typst compilations that run at once.
+type SchedulerConfig struct {
+	// Workers is the number of compilations that run at the same time.
+	Workers int
+	// QueueSize is the number of jobs that may wait for a worker. Further
+	// jobs are rejected with ErrQueueFull.
+	QueueSize int
+	// Timeout limits how long a job may run once it has a worker. Zero
+	// means no limit.
+	Timeout time.Duration
+}
+
+// DefaultSchedulerConfig runs one compilation per CPU and lets four times as
+// many wait.
+func DefaultSchedulerConfig() SchedulerConfig {
+	workers := runtime.NumCPU()
+	return SchedulerConfig{
+		Workers:   workers,
+		QueueSize: 4 * workers,
+		Timeout:   10 * time.Second,
+	}
+}
+
+// SchedulerStats is a snapshot of a scheduler's queue, for monitoring.
+type SchedulerStats struct {
+	Workers   int
+	QueueSize int
+	// Running and Queued are the jobs running and waiting right now.
+	Running int
+	Queued  int
+	// Completed counts finished jobs, whether they succeeded or not.
+	Completed uint64
+	Rejected  uint64
+	TimedOut  uint64
+	// WaitTotal and WaitMax measure the time jobs waited for a worker.
+	WaitTotal time.Duration
+	WaitMax   time.Duration
+	// RunTotal is the time completed jobs spent running.
+	RunTotal time.Duration
+}
+
+// Scheduler runs compile jobs on a bounded number of workers.
+type Scheduler struct {
+	config SchedulerConfig
+	slots  chan struct{}
+	mutex  sync.Mutex
+	stats  SchedulerStats
+}
+
+func NewScheduler(cfg SchedulerConfig) (*Scheduler, error) {
+	if cfg.Workers < 1 {
+		return nil, fmt.Errorf("scheduler needs at least one worker, got %d", cfg.Workers)
+	}
+	if cfg.QueueSize < 0 || cfg.Timeout < 0 {
+		return nil, fmt.Errorf("scheduler queue size and timeout must not be negative")
+	}
+	return &Scheduler{
+		config: cfg,
+		slots:  make(chan struct{}, cfg.Workers),
+		stats:  SchedulerStats{Workers: cfg.Workers, QueueSize: cfg.QueueSize},
+	}, nil
+}
+
+// Run waits for a free worker and runs job on it, with a context that ends
+// after the configured timeout. It returns ErrQueueFull without waiting when
+// the queue is full, and ctx's error if ctx ends while the job is queued.
+func (s *Scheduler) Run(ctx context.Context, job func(context.Context) error) error {
+	queuedAt := time.Now()
+	if err := s.acquire(ctx); err != nil {
+		return err
+	}
+	s.started(time.Since(queuedAt))
+
+	startedAt := time.Now()
+
+	jobCtx, cancel := ctx, context.CancelFunc(func() {})
+	if s.config.Timeout > 0 {
+		jobCtx, cancel = context.WithTimeout(ctx, s.config.Timeout)
+	}
+	defer cancel()
+	// Deferred after cancel so it runs first and still sees a timeout. A job
+	// that panics gives its worker back all the same.
+	defer s.finished(ctx, jobCtx, startedAt)
+
+	return job(jobCtx)
+}
+
+// finished releases the worker slot of a job that started at startedAt and
+// records it.
+func (s *Scheduler) finished(ctx, jobCtx context.Context, startedAt time.Time) {
+	<-s.slots
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stats.Running--
+	s.stats.Completed++
+	s.stats.RunTotal += time.Since(startedAt)
+	if errors.Is(jobCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		s.stats.TimedOut++
+	}
+}
+
+// acquire takes a worker slot, queueing for one if all are busy.
+func (s *Scheduler) acquire(ctx context.Context) error {
+	select {
+	case s.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	s.mutex.Lock()
+	if s.stats.Queued >= s.config.QueueSize {
+		s.stats.Rejected++
+		s.mutex.Unlock()
+		return ErrQueueFull
+	}
+	s.stats.Queued++
+	s.mutex.Unlock()
+
+	defer func() {
+		s.mutex.Lock()
+		s.stats.Queued--
+		s.mutex.Unlock()
+	}()
+
+	select {
+	case s.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for a compile worker: %w", ctx.Err())
+	}
+}
+
+func (s *Scheduler) started(wait time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.stats.Running++
+	s.stats.WaitTotal += wait
+	s.stats.WaitMax = max(s.stats.WaitMax, wait)
+}
+
+// Stats returns the scheduler's current queue and counters.
+func (s *Scheduler) Stats() SchedulerStats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.stats
+}
+
+// RetryAfter estimates how long a rejected client should wait before trying
+// again: the time the workers take to work through the queue, at the average
+// run time of past jobs. It is at least a second.
+func (s *Scheduler) RetryAfter() time.Duration {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stats.Completed == 0 {
+		return time.Second
+	}
+	average := s.stats.RunTotal / time.Duration(s.stats.Completed)
+	rounds := (s.stats.Queued + s.config.Workers) / s.config.Workers
+	return max(time.Second, average*time.Duration(rounds))
+}
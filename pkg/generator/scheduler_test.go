@@ -0,0 +1,186 @@
+package generator_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/generator"
+)
+
+func newScheduler(t *testing.T, cfg generator.SchedulerConfig) *generator.Scheduler {
+	t.Helper()
+	scheduler, err := generator.NewScheduler(cfg)
+	if err != nil {
+		t.Fatalf("NewScheduler failed: %v", err)
+	}
+	return scheduler
+}
+
+// waitFor polls the scheduler until its stats satisfy ready.
+func waitFor(t *testing.T, scheduler *generator.Scheduler, ready func(generator.SchedulerStats) bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !ready(scheduler.Stats()) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Scheduler did not reach the expected state: %+v", scheduler.Stats())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestNewSchedulerInvalid(t *testing.T) {
+	t.Parallel()
+	for _, cfg := range []generator.SchedulerConfig{{Workers: 0}, {Workers: 1, QueueSize: -1}, {Workers: 1, Timeout: -time.Second}} {
+		if _, err := generator.NewScheduler(cfg); err == nil {
+			t.Errorf("Expected an error for %+v", cfg)
+		}
+	}
+}
+
+func TestSchedulerLimitsConcurrency(t *testing.T) {
+	t.Parallel()
+	scheduler := newScheduler(t, generator.SchedulerConfig{Workers: 2, QueueSize: 10})
+
+	var running, peak atomic.Int32
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			err := scheduler.Run(context.Background(), func(context.Context) error {
+				current := running.Add(1)
+				for {
+					old := peak.Load()
+					if current <= old || peak.CompareAndSwap(old, current) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				running.Add(-1)
+				return nil
+			})
+			if err != nil {
+				t.Errorf("Run failed: %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	if peak.Load() > 2 {
+		t.Errorf("Expected at most 2 concurrent jobs, got %d", peak.Load())
+	}
+	stats := scheduler.Stats()
+	if stats.Completed != 8 || stats.Running != 0 || stats.Queued != 0 {
+		t.Errorf("Unexpected stats after all jobs: %+v", stats)
+	}
+}
+
+func TestSchedulerRejectsWhenQueueFull(t *testing.T) {
+	t.Parallel()
+	scheduler := newScheduler(t, generator.SchedulerConfig{Workers: 1, QueueSize: 1})
+
+	release := make(chan struct{})
+	block := func(context.Context) error {
+		<-release
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	wg.Go(func() { _ = scheduler.Run(context.Background(), block) })
+	waitFor(t, scheduler, func(s generator.SchedulerStats) bool { return s.Running == 1 })
+	wg.Go(func() { _ = scheduler.Run(context.Background(), block) })
+	waitFor(t, scheduler, func(s generator.SchedulerStats) bool { return s.Queued == 1 })
+
+	err := scheduler.Run(context.Background(), block)
+	if !errors.Is(err, generator.ErrQueueFull) {
+		t.Errorf("Expected ErrQueueFull, got %v", err)
+	}
+	if retry := scheduler.RetryAfter(); retry < time.Second {
+		t.Errorf("Expected a retry delay of at least a second, got %v", retry)
+	}
+
+	close(release)
+	wg.Wait()
+
+	stats := scheduler.Stats()
+	if stats.Rejected != 1 || stats.Completed != 2 || stats.WaitMax <= 0 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestSchedulerQueuedJobCanceled(t *testing.T) {
+	t.Parallel()
+	scheduler := newScheduler(t, generator.SchedulerConfig{Workers: 1, QueueSize: 1})
+
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = scheduler.Run(context.Background(), func(context.Context) error {
+			<-release
+			return nil
+		})
+	}()
+	waitFor(t, scheduler, func(s generator.SchedulerStats) bool { return s.Running == 1 })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ran := false
+	err := scheduler.Run(ctx, func(context.Context) error {
+		ran = true
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || ran {
+		t.Errorf("Expected the queued job to be canceled without running, got %v", err)
+	}
+
+	close(release)
+	<-done
+}
+
+func TestSchedulerTimeout(t *testing.T) {
+	t.Parallel()
+	scheduler := newScheduler(t, generator.SchedulerConfig{Workers: 1, Timeout: 10 * time.Millisecond})
+
+	err := scheduler.Run(context.Background(), func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the job to time out, got %v", err)
+	}
+	if stats := scheduler.Stats(); stats.TimedOut != 1 {
+		t.Errorf("Expected one timed out job, got %+v", stats)
+	}
+}
+
+func TestSchedulerReleasesWorkerOnPanic(t *testing.T) {
+	t.Parallel()
+	scheduler := newScheduler(t, generator.SchedulerConfig{Workers: 1})
+
+	for range 3 {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("Expected the job's panic to reach the caller")
+				}
+			}()
+			_ = scheduler.Run(context.Background(), func(context.Context) error {
+				panic("job failed")
+			})
+		}()
+	}
+
+	ran := false
+	if err := scheduler.Run(context.Background(), func(context.Context) error {
+		ran = true
+		return nil
+	}); err != nil || !ran {
+		t.Fatalf("Expected a later job to run, got %v", err)
+	}
+	if stats := scheduler.Stats(); stats.Running != 0 || stats.Completed != 4 {
+		t.Errorf("Unexpected stats after panics: %+v", stats)
+	}
+}
@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultWorkDir is the root of the work directories when the configuration
+// names none.
+const DefaultWorkDir = "temp"
+
+// workDirPrefix marks the directories created for generations, so the sweep
+// only removes those.
+const workDirPrefix = "cv-"
+
+// workRoot returns the directory under which each generation gets its own
+// work directory.
+func (g *CVGenerator) workRoot() string {
+	if g.config.WorkDir != "" {
+		return g.config.WorkDir
+	}
+	return DefaultWorkDir
+}
+
+// newWorkDir creates a work directory for one generation that only the
+// server's user can read. The returned function removes it; callers defer it
+// so the directory is removed on errors, cancellation and panics alike.
+func (g *CVGenerator) newWorkDir(templateKey string) (string, func(), error) {
+	root := g.workRoot()
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return "", nil, fmt.Errorf("failed to create work root: %w", err)
+	}
+
+	// MkdirTemp picks a name no concurrent generation uses and creates the
+	// directory with mode 0700
+	dir, err := os.MkdirTemp(root, workDirPrefix+templateKey+"-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create work directory: %w", err)
+	}
+	return dir, func() {
+		if err := os.RemoveAll(dir); err != nil {
+			fmt.Printf("Failed to remove work directory %s: %v\n", dir, err)
+		}
+	}, nil
+}
+
+// SweepWorkDirs removes the work directories left behind by a process that
+// crashed mid-generation and returns how many it removed. It is meant to run
+// at startup, so the work root must not be shared with a running server.
+func (g *CVGenerator) SweepWorkDirs() (int, error) {
+	root := g.workRoot()
+	entries, err := os.ReadDir(root)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read work root: %w", err)
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), workDirPrefix) {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(root, entry.Name())); err != nil {
+			return removed, fmt.Errorf("failed to remove stale work directory: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
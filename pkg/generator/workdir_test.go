@@ -0,0 +1,131 @@
+package generator_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/config"
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+	"github.com/AlexTLDR/mycv.quest/pkg/generator"
+	"github.com/AlexTLDR/mycv.quest/pkg/renderer"
+)
+
+// probeRenderer records the work directories that exist while it renders,
+// and panics when asked to.
+type probeRenderer struct {
+	renderer.Basic
+	root  string
+	seen  *[]os.FileInfo
+	panic bool
+}
+
+func (p probeRenderer) Render(data *cv.CV, avatar string) (map[string][]byte, error) {
+	entries, _ := os.ReadDir(p.root)
+	for _, entry := range entries {
+		if info, err := entry.Info(); err == nil {
+			*p.seen = append(*p.seen, info)
+		}
+	}
+	if p.panic {
+		panic("render failed")
+	}
+	return p.Basic.Render(data, avatar)
+}
+
+func probeGenerator(root string, probe probeRenderer) *generator.CVGenerator {
+	return generator.NewWithOptions(&config.Config{
+		Templates: map[string]config.Template{
+			"basic": {
+				Name:      "Basic Resume",
+				Dir:       "../../templates/basic/template",
+				InputFile: "main.typ",
+				Renderer:  probe,
+			},
+		},
+		WorkDir: root,
+	}, generator.Options{Compiler: &generator.FakeCompiler{}})
+}
+
+func TestWorkDirIsPrivateAndRemoved(t *testing.T) {
+	t.Parallel()
+	root := filepath.Join(t.TempDir(), "work")
+	var seen []os.FileInfo
+	gen := probeGenerator(root, probeRenderer{root: root, seen: &seen})
+
+	// Equal CVs share a cache entry, so the second one differs
+	_, _ = gen.GenerateFromCV(context.Background(), "basic", &cv.CV{})
+	_, _ = gen.GenerateFromCV(context.Background(), "basic", &cv.CV{Person: cv.Person{Name: "Jane"}})
+
+	if len(seen) != 2 || seen[0].Name() == seen[1].Name() {
+		t.Fatalf("Expected one work directory per generation, saw %v", seen)
+	}
+	for _, info := range seen {
+		if !info.IsDir() || info.Mode().Perm() != 0o700 {
+			t.Errorf("Work directory %s has mode %v, want 0700", info.Name(), info.Mode())
+		}
+	}
+	if entries, _ := os.ReadDir(root); len(entries) != 0 {
+		t.Errorf("Work directories were not removed: %v", entries)
+	}
+}
+
+func TestWorkDirRemovedOnPanic(t *testing.T) {
+	t.Parallel()
+	root := filepath.Join(t.TempDir(), "work")
+	var seen []os.FileInfo
+	gen := probeGenerator(root, probeRenderer{root: root, seen: &seen, panic: true})
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected the renderer to panic")
+			}
+		}()
+		_, _ = gen.GenerateFromCV(context.Background(), "basic", &cv.CV{})
+	}()
+
+	if len(seen) != 1 {
+		t.Fatalf("Expected a work directory during the render, saw %v", seen)
+	}
+	if entries, _ := os.ReadDir(root); len(entries) != 0 {
+		t.Errorf("Work directory was not removed after the panic: %v", entries)
+	}
+}
+
+func TestSweepWorkDirs(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	for _, dir := range []string{"cv-basic-123/fonts", "cv-modern-456", "unrelated"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o700); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "cv-notes.txt"), nil, 0o600); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+
+	gen := generator.New(&config.Config{WorkDir: root})
+	removed, err := gen.SweepWorkDirs()
+	if err != nil {
+		t.Fatalf("SweepWorkDirs failed: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Expected 2 removed directories, got %d", removed)
+	}
+
+	entries, _ := os.ReadDir(root)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if len(names) != 2 || names[0] != "cv-notes.txt" || names[1] != "unrelated" {
+		t.Errorf("Sweep removed the wrong entries, left %v", names)
+	}
+
+	missing := generator.New(&config.Config{WorkDir: filepath.Join(root, "missing")})
+	if removed, err := missing.SweepWorkDirs(); removed != 0 || err != nil {
+		t.Errorf("Expected nothing to sweep in a missing root, got %d, %v", removed, err)
+	}
+}
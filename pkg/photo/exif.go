@@ -0,0 +1,172 @@
+package photo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+)
+
+// orientationTag is the EXIF tag telling how a camera held the image: 1 is
+// upright, 2 to 8 are the mirrorings and rotations to undo.
+const orientationTag = 0x0112
+
+// exifHeader starts the EXIF block of JPEG files, and of some WebP files.
+var exifHeader = []byte("Exif\x00\x00")
+
+// orientation returns the EXIF orientation of an image file of the given
+// format, or 1 when it has none.
+func orientation(data []byte, format string) int {
+	var tiff []byte
+	switch format {
+	case "jpeg":
+		tiff = jpegExif(data)
+	case "png":
+		tiff = pngExif(data)
+	case "webp":
+		tiff = webpExif(data)
+	default:
+	}
+	if value := tiffOrientation(tiff); value >= 1 && value <= 8 {
+		return value
+	}
+	return 1
+}
+
+// jpegExif returns the TIFF data of a JPEG file's APP1 EXIF segment. The
+// segments before the image data each start with a marker and a length.
+func jpegExif(data []byte) []byte {
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return nil
+		}
+		marker := data[i+1]
+		if marker == 0xFF {
+			// Fill byte before a marker
+			i++
+			continue
+		}
+		if marker == 0xDA || marker == 0xD9 {
+			// The image data starts, and the metadata has ended
+			return nil
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		end := i + 2 + length
+		if length < 2 || end > len(data) {
+			return nil
+		}
+		if segment := data[i+4 : end]; marker == 0xE1 && bytes.HasPrefix(segment, exifHeader) {
+			return segment[len(exifHeader):]
+		}
+		i = end
+	}
+	return nil
+}
+
+// pngExif returns the TIFF data of a PNG file's eXIf chunk.
+func pngExif(data []byte) []byte {
+	for i := 8; i+8 <= len(data); {
+		length := int(binary.BigEndian.Uint32(data[i:]))
+		end := i + 8 + length
+		if length < 0 || end > len(data) {
+			return nil
+		}
+		if string(data[i+4:i+8]) == "eXIf" {
+			return data[i+8 : end]
+		}
+		// Skip the chunk and its CRC
+		i = end + 4
+	}
+	return nil
+}
+
+// webpExif returns the TIFF data of a WebP file's EXIF chunk. RIFF chunks are
+// padded to an even length.
+func webpExif(data []byte) []byte {
+	for i := 12; i+8 <= len(data); {
+		length := int(binary.LittleEndian.Uint32(data[i+4:]))
+		end := i + 8 + length
+		if length < 0 || end > len(data) {
+			return nil
+		}
+		if string(data[i:i+4]) == "EXIF" {
+			return bytes.TrimPrefix(data[i+8:end], exifHeader)
+		}
+		i = end + length%2
+	}
+	return nil
+}
+
+// tiffOrientation reads the orientation tag from the first directory of EXIF
+// TIFF data, or returns 0 when it is missing.
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	dir := int(order.Uint32(tiff[4:]))
+	if dir < 8 || dir+2 > len(tiff) {
+		return 0
+	}
+	count := int(order.Uint16(tiff[dir:]))
+	for i := range count {
+		entry := dir + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:]) == orientationTag {
+			// A SHORT value is stored at the start of the entry's value field
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+	return 0
+}
+
+// orient turns an image upright according to its EXIF orientation.
+func orient(img *image.RGBA, orientation int) *image.RGBA {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	// Orientations 5 to 8 turn the image a quarter, swapping its sides
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := range dh {
+		for x := range dw {
+			// The source pixel each destination pixel comes from
+			sx, sy := x, y
+			switch orientation {
+			case 2: // mirrored
+				sx = w - 1 - x
+			case 3: // upside down
+				sx, sy = w-1-x, h-1-y
+			case 4: // mirrored upside down
+				sy = h - 1 - y
+			case 5: // mirrored and turned left
+				sx, sy = y, x
+			case 6: // turned left, so turn right
+				sx, sy = y, h-1-x
+			case 7: // mirrored and turned right
+				sx, sy = w-1-y, h-1-x
+			case 8: // turned right, so turn left
+				sx, sy = w-1-y, x
+			default:
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):][:4], img.Pix[img.PixOffset(sx, sy):][:4])
+		}
+	}
+	return dst
+}
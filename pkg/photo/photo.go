@@ -0,0 +1,195 @@
+// Package photo normalizes uploaded portraits before they reach Typst. It
+// accepts PNG, JPEG and WebP, turns the image upright as its EXIF orientation
+// asks, crops it square around a focal point, scales it down to the size the
+// template shows and encodes it again. Re-encoding drops every piece of the
+// original metadata, such as the camera and the GPS position.
+package photo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"strconv"
+	"strings"
+
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // registers the WebP decoder
+)
+
+// DefaultSize is the side in pixels of photos whose template names no size.
+const DefaultSize = 400
+
+// MaxPixels bounds the images that are decoded, so that a small file
+// claiming huge dimensions cannot exhaust memory. Photos of this size, above
+// the usual 12 megapixels of a phone, take up to 64 MB decoded, and twice
+// that while they are turned upright.
+const MaxPixels = 16_000_000
+
+// jpegQuality keeps portraits free of visible artifacts at a fraction of the
+// size of a PNG.
+const jpegQuality = 90
+
+var (
+	// ErrUnsupported is returned for uploads that are not PNG, JPEG or WebP
+	// images, or that cannot be decoded.
+	ErrUnsupported = errors.New("not a PNG, JPEG or WebP image")
+	// ErrTooLarge is returned for images of more than MaxPixels pixels.
+	ErrTooLarge = errors.New("image is too large")
+)
+
+// Focus is the point a photo is cropped around, as fractions of its width and
+// height from the top left corner.
+type Focus struct {
+	X, Y float64
+}
+
+// Center crops photos around their middle.
+var Center = Focus{X: 0.5, Y: 0.5}
+
+// focusNames are the positions the form offers, named like CSS
+// object-position keywords.
+var focusNames = map[string]Focus{
+	"center":       Center,
+	"top":          {X: 0.5, Y: 0},
+	"bottom":       {X: 0.5, Y: 1},
+	"left":         {X: 0, Y: 0.5},
+	"right":        {X: 1, Y: 0.5},
+	"top left":     {X: 0, Y: 0},
+	"top right":    {X: 1, Y: 0},
+	"bottom left":  {X: 0, Y: 1},
+	"bottom right": {X: 1, Y: 1},
+}
+
+// ParseFocus reads a focal point given as a position name such as "top" or
+// "bottom left", or as two percentages such as "50% 30%". An empty string is
+// the centre.
+func ParseFocus(s string) (Focus, error) {
+	s = strings.ToLower(strings.Join(strings.Fields(s), " "))
+	if s == "" {
+		return Center, nil
+	}
+	if focus, exists := focusNames[s]; exists {
+		return focus, nil
+	}
+
+	x, y, ok := strings.Cut(s, " ")
+	if ok {
+		fx, errX := parsePercent(x)
+		fy, errY := parsePercent(y)
+		if errX == nil && errY == nil {
+			return Focus{X: fx, Y: fy}, nil
+		}
+	}
+	return Focus{}, fmt.Errorf("invalid photo focus %q (want a position such as top, or percentages such as 50%% 30%%)", s)
+}
+
+func parsePercent(s string) (float64, error) {
+	number, ok := strings.CutSuffix(s, "%")
+	if !ok {
+		return 0, fmt.Errorf("%q is not a percentage", s)
+	}
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil || value < 0 || value > 100 {
+		return 0, fmt.Errorf("%q is not a percentage between 0%% and 100%%", s)
+	}
+	return value / 100, nil
+}
+
+// Options control how Process normalizes a photo.
+type Options struct {
+	// Size is the side in pixels of the square result. Zero uses
+	// DefaultSize. Smaller photos are cropped but not scaled up.
+	Size int
+	// Focus is the point the crop is centred on, as close as the image's
+	// edges allow. Nil crops around the centre.
+	Focus *Focus
+	// PNG encodes every photo as PNG, for callers that write it under a fixed
+	// name. Otherwise opaque photos are encoded as JPEG.
+	PNG bool
+}
+
+// Photo is a normalized photo.
+type Photo struct {
+	Data []byte
+	// Ext is the file extension of Data without the dot: "jpg" or "png".
+	Ext string
+}
+
+// Process decodes an uploaded image and returns it upright, square, scaled
+// down and stripped of metadata.
+func Process(data []byte, opts Options) (Photo, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return Photo{}, fmt.Errorf("%w: %w", ErrUnsupported, err)
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return Photo{}, ErrUnsupported
+	}
+	if config.Width*config.Height > MaxPixels {
+		return Photo{}, fmt.Errorf("%w: %dx%d pixels", ErrTooLarge, config.Width, config.Height)
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return Photo{}, fmt.Errorf("%w: %w", ErrUnsupported, err)
+	}
+
+	img := orient(toRGBA(decoded), orientation(data, format))
+	cropped := crop(img, opts.focus())
+
+	size := opts.Size
+	if size <= 0 {
+		size = DefaultSize
+	}
+	size = min(size, cropped.Dx())
+
+	scaled := image.NewRGBA(image.Rect(0, 0, size, size))
+	xdraw.CatmullRom.Scale(scaled, scaled.Bounds(), img, cropped, draw.Src, nil)
+
+	var buf bytes.Buffer
+	if opts.PNG || !scaled.Opaque() {
+		if err := png.Encode(&buf, scaled); err != nil {
+			return Photo{}, fmt.Errorf("failed to encode photo: %w", err)
+		}
+		return Photo{Data: buf.Bytes(), Ext: "png"}, nil
+	}
+	if err := jpeg.Encode(&buf, scaled, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return Photo{}, fmt.Errorf("failed to encode photo: %w", err)
+	}
+	return Photo{Data: buf.Bytes(), Ext: "jpg"}, nil
+}
+
+func (o Options) focus() Focus {
+	if o.Focus == nil {
+		return Center
+	}
+	return *o.Focus
+}
+
+// crop returns the largest square of img centred on focus, moved inside the
+// image where the focus is near an edge.
+func crop(img *image.RGBA, focus Focus) image.Rectangle {
+	bounds := img.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	left := clamp(int(math.Round(focus.X*float64(bounds.Dx())))-side/2, 0, bounds.Dx()-side)
+	top := clamp(int(math.Round(focus.Y*float64(bounds.Dy())))-side/2, 0, bounds.Dy()-side)
+	return image.Rect(left, top, left+side, top+side).Add(bounds.Min)
+}
+
+func clamp(value, low, high int) int {
+	return max(low, min(value, high))
+}
+
+// toRGBA copies an image into an RGBA image with its origin at 0,0, which the
+// standard library converts JPEG and PNG images to quickly.
+func toRGBA(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+	return rgba
+}
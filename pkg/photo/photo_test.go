@@ -0,0 +1,224 @@
+package photo_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/photo"
+)
+
+var (
+	red  = color.RGBA{R: 255, A: 255}
+	blue = color.RGBA{B: 255, A: 255}
+)
+
+// halves returns a w×h image, red on the left half and blue on the right.
+func halves(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			if x < w/2 {
+				img.Set(x, y, red)
+			} else {
+				img.Set(x, y, blue)
+			}
+		}
+	}
+	return img
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100}); err != nil {
+		t.Fatalf("Failed to encode JPEG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("Failed to encode PNG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// withOrientation inserts an EXIF segment with the given orientation after
+// the start marker of a JPEG file, as cameras write it.
+func withOrientation(jpegData []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x01")
+	entry := make([]byte, 12)
+	binary.BigEndian.PutUint16(entry[0:], 0x0112)
+	binary.BigEndian.PutUint16(entry[2:], 3) // SHORT
+	binary.BigEndian.PutUint32(entry[4:], 1)
+	binary.BigEndian.PutUint16(entry[8:], orientation)
+	tiff = append(tiff, entry...)
+	tiff = append(tiff, 0, 0, 0, 0) // no next directory
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	header := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(header[2:], uint16(len(segment)+2))
+
+	out := append([]byte{}, jpegData[:2]...)
+	out = append(out, header...)
+	out = append(out, segment...)
+	return append(out, jpegData[2:]...)
+}
+
+func decode(t *testing.T, p photo.Photo) image.Image {
+	t.Helper()
+	img, _, err := image.Decode(bytes.NewReader(p.Data))
+	if err != nil {
+		t.Fatalf("Processed photo does not decode: %v", err)
+	}
+	return img
+}
+
+func isRed(c color.Color) bool {
+	r, _, b, _ := c.RGBA()
+	return r > 0xC000 && b < 0x4000
+}
+
+func isBlue(c color.Color) bool {
+	r, _, b, _ := c.RGBA()
+	return b > 0xC000 && r < 0x4000
+}
+
+func TestProcessCropsAndScales(t *testing.T) {
+	t.Parallel()
+	data := encodeJPEG(t, halves(800, 400))
+
+	for _, test := range []struct {
+		focus string
+		check func(color.Color) bool
+	}{
+		{"left", isRed},
+		{"right", isBlue},
+		{"90% 50%", isBlue},
+	} {
+		focus, err := photo.ParseFocus(test.focus)
+		if err != nil {
+			t.Fatalf("ParseFocus(%q) failed: %v", test.focus, err)
+		}
+		result, err := photo.Process(data, photo.Options{Size: 200, Focus: &focus})
+		if err != nil {
+			t.Fatalf("Process failed: %v", err)
+		}
+		img := decode(t, result)
+		if result.Ext != "jpg" || img.Bounds().Dx() != 200 || img.Bounds().Dy() != 200 {
+			t.Errorf("Expected a 200×200 JPEG, got %s %v", result.Ext, img.Bounds())
+		}
+		if center := img.At(100, 100); !test.check(center) {
+			t.Errorf("Focus %q: unexpected colour %v in the middle", test.focus, center)
+		}
+	}
+
+	// The centre crop spans both halves, and small photos are not scaled up
+	result, err := photo.Process(data, photo.Options{Size: 1000})
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	img := decode(t, result)
+	if img.Bounds().Dx() != 400 || !isRed(img.At(50, 200)) || !isBlue(img.At(350, 200)) {
+		t.Errorf("Expected a 400×400 centre crop, got %v", img.Bounds())
+	}
+}
+
+func TestProcessOrientation(t *testing.T) {
+	t.Parallel()
+	// A camera turned left stores the photo sideways: what is up in the
+	// picture is on the left of the pixels, so turning right puts red on top.
+	data := withOrientation(encodeJPEG(t, halves(80, 40)), 6)
+
+	top := photo.Focus{X: 0.5, Y: 0}
+	result, err := photo.Process(data, photo.Options{Focus: &top})
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	img := decode(t, result)
+	if img.Bounds().Dx() != 40 || !isRed(img.At(20, 20)) {
+		t.Errorf("Expected the upright photo's red top half, got %v at the centre of %v", img.At(20, 20), img.Bounds())
+	}
+	if bytes.Contains(result.Data, []byte("Exif")) {
+		t.Error("Expected the EXIF metadata to be stripped")
+	}
+
+	bottom := photo.Focus{X: 0.5, Y: 1}
+	result, err = photo.Process(data, photo.Options{Focus: &bottom})
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if img := decode(t, result); !isBlue(img.At(20, 20)) {
+		t.Errorf("Expected the upright photo's blue bottom half, got %v", img.At(20, 20))
+	}
+}
+
+func TestProcessKeepsTransparency(t *testing.T) {
+	t.Parallel()
+	img := halves(100, 100)
+	img.Set(0, 0, color.RGBA{})
+
+	result, err := photo.Process(encodePNG(t, img), photo.Options{})
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if result.Ext != "png" {
+		t.Errorf("Expected a transparent photo to stay PNG, got %s", result.Ext)
+	}
+
+	result, err = photo.Process(encodeJPEG(t, img), photo.Options{PNG: true})
+	if err != nil || result.Ext != "png" || !bytes.HasPrefix(result.Data, []byte("\x89PNG")) {
+		t.Errorf("Expected a PNG when asked for one, got %s, %v", result.Ext, err)
+	}
+}
+
+func TestProcessRejects(t *testing.T) {
+	t.Parallel()
+	gif := []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00;")
+	for name, data := range map[string][]byte{
+		"text":      []byte("fake image data"),
+		"gif":       gif,
+		"truncated": encodePNG(t, halves(10, 10))[:40],
+	} {
+		if _, err := photo.Process(data, photo.Options{}); !errors.Is(err, photo.ErrUnsupported) {
+			t.Errorf("%s: expected ErrUnsupported, got %v", name, err)
+		}
+	}
+
+	// A small file may claim huge dimensions in its header
+	huge := encodePNG(t, halves(10, 10))
+	binary.BigEndian.PutUint32(huge[16:], 5000)
+	binary.BigEndian.PutUint32(huge[20:], 4000)
+	binary.BigEndian.PutUint32(huge[29:], crc32.ChecksumIEEE(huge[12:29]))
+	if _, err := photo.Process(huge, photo.Options{}); !errors.Is(err, photo.ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge, got %v", err)
+	}
+}
+
+func TestParseFocus(t *testing.T) {
+	t.Parallel()
+	for input, expected := range map[string]photo.Focus{
+		"":              photo.Center,
+		"top":           {X: 0.5, Y: 0},
+		" Bottom  Left": {X: 0, Y: 1},
+		"25% 75%":       {X: 0.25, Y: 0.75},
+	} {
+		if focus, err := photo.ParseFocus(input); err != nil || focus != expected {
+			t.Errorf("ParseFocus(%q) = %v, %v, want %v", input, focus, err, expected)
+		}
+	}
+	for _, input := range []string{"middle", "50%", "120% 0%", "a% b%"} {
+		if _, err := photo.ParseFocus(input); err == nil {
+			t.Errorf("ParseFocus(%q) should fail", input)
+		}
+	}
+}
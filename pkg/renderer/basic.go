@@ -0,0 +1,103 @@
+package renderer
+
+import (
+	"strings"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+	"github.com/AlexTLDR/mycv.quest/pkg/richtext"
+	"github.com/AlexTLDR/mycv.quest/pkg/typst"
+	"github.com/AlexTLDR/mycv.quest/pkg/utils"
+)
+
+// Basic renders the basic-resume template.
+type Basic struct{}
+
+func (Basic) Key() string { return "basic" }
+
+func (Basic) Metadata() Metadata {
+	return Metadata{
+		Name:        "Basic Resume",
+		Description: "Simple and elegant layout perfect for any industry",
+		EntryFile:   "cv.typ",
+		Font:        "New Computer Modern",
+		Paper:       cv.PaperUSLetter,
+		DateFormat:  cv.DateShort,
+	}
+}
+
+func (Basic) SourceFiles() []string { return nil }
+
+func (Basic) Render(data *cv.CV, _ string) (map[string][]byte, error) {
+	view, err := RenderBasicData(data)
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{ViewFile: view, richtext.LibraryFile: richtext.Library}, nil
+}
+
+// RenderBasicData renders a CV as the data file the basic template's cv.typ
+// reads.
+func RenderBasicData(data *cv.CV) ([]byte, error) {
+	layout := resolveLayout(data, Basic{}.Metadata())
+	accentColor := data.Theme.AccentColor
+	if accentColor == "" {
+		accentColor = "#26428b"
+	}
+
+	view := map[string]interface{}{
+		"name":          typst.Data(data.Person.Name),
+		"location":      typst.Data(data.Contact.Location),
+		"email":         typst.Data(data.Contact.Email),
+		"github":        typst.Data(utils.NormalizeURL(data.Contact.GitHub.URL)),
+		"linkedin":      typst.Data(utils.NormalizeURL(data.Contact.LinkedIn.URL)),
+		"phone":         typst.Data(data.Contact.Phone),
+		"personal_site": typst.Data(utils.NormalizeURL(data.Contact.Website.URL)),
+		"accent_color":  typst.Data(accentColor),
+		"font":          typst.Data(fontFamily(data, Basic{}.Metadata().Font)),
+	}
+	layout.addTo(view)
+
+	education := []map[string]interface{}{}
+	for _, edu := range data.Education {
+		education = append(education, map[string]interface{}{
+			"institution": typst.Data(edu.Institution),
+			"location":    typst.Data(edu.Location),
+			"degree":      typst.Data(edu.Degree),
+			"from":        layout.date(edu.StartDate),
+			"to":          layout.date(edu.EndDate),
+			"description": richText(edu.Description),
+		})
+	}
+	view["education"] = education
+
+	work := []map[string]interface{}{}
+	for _, job := range data.Experience {
+		work = append(work, map[string]interface{}{
+			"title":       typst.Data(job.Title),
+			"location":    typst.Data(job.Location),
+			"company":     typst.Data(job.Company),
+			"from":        layout.date(job.StartDate),
+			"to":          layout.date(job.EndDate),
+			"description": richText(job.Description),
+		})
+	}
+	view["work"] = work
+
+	projects := []map[string]interface{}{}
+	for _, project := range data.Projects {
+		projects = append(projects, map[string]interface{}{
+			"name":        typst.Data(project.Name),
+			"role":        typst.Data(project.Role),
+			"url":         typst.Data(project.URL),
+			"from":        layout.date(project.StartDate),
+			"to":          layout.date(project.EndDate),
+			"description": richText(project.Description),
+		})
+	}
+	view["projects"] = projects
+
+	view["programming_languages"] = typst.Data(strings.Join(data.SkillNames(cv.CategoryProgramming, cv.CategoryExpertise), ", "))
+	view["technologies"] = typst.Data(strings.Join(data.SkillNames(cv.CategoryTechnologies, cv.CategoryTools, ""), ", "))
+
+	return encodeView(view)
+}
@@ -0,0 +1,146 @@
+package renderer_test
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/renderer"
+)
+
+func TestGenerateBasicTypContent(t *testing.T) {
+	t.Parallel()
+	// Create test form data
+	formData := url.Values{
+		"name":                      {"Jane Smith"},
+		"location":                  {"Austin, TX"},
+		"email":                     {"jane.smith@example.com"},
+		"github":                    {"janesmith"},
+		"linkedin":                  {"janesmith"},
+		"phone":                     {"+1-555-987-6543"},
+		"personal_site":             {"https://janesmith.dev"},
+		"accent_color":              {"#ff6b6b"},
+		"education[0][institution]": {"State University"},
+		"education[0][location]":    {"Austin, TX"},
+		"education[0][start_date]":  {"2019"},
+		"education[0][end_date]":    {"2023"},
+		"education[0][degree]":      {"Master of Science in Software Engineering"},
+		"work[0][title]":            {"Senior Developer"},
+		"work[0][company]":          {"Innovation Labs"},
+		"work[0][location]":         {"Remote"},
+		"work[0][start_date]":       {"2023"},
+		"work[0][end_date]":         {"Present"},
+		"work[0][description]":      {"Lead development of microservices architecture"},
+		"programming_languages":     {"Rust, TypeScript, Go"},
+		"technologies":              {"Kubernetes, PostgreSQL, Redis"},
+	}
+
+	req := &http.Request{
+		Method: http.MethodPost,
+		Header: make(http.Header),
+		Form:   formData,
+	}
+
+	content := renderBasic(t, req)
+
+	// Test that content includes expected sections
+	expectedSections := []string{
+		`"education": [`,
+		`"work": [`,
+		`"programming_languages": "Rust, TypeScript, Go"`,
+		"Jane Smith",
+		"jane.smith@example.com",
+		"State University",
+		"Senior Developer",
+		"Innovation Labs",
+		"Rust, TypeScript, Go",
+		"Kubernetes, PostgreSQL, Redis",
+	}
+
+	for _, expected := range expectedSections {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Generated content missing expected section: %s", expected)
+		}
+	}
+
+	// Test accent color
+	if !strings.Contains(content, "#ff6b6b") {
+		t.Error("Generated content missing custom accent color")
+	}
+}
+
+func TestGenerateBasicTypContentEmpty(t *testing.T) {
+	t.Parallel()
+	// Create empty request
+	req := &http.Request{
+		Method: http.MethodPost,
+		Header: make(http.Header),
+		Form:   url.Values{},
+	}
+
+	content := renderBasic(t, req)
+
+	// Should still generate valid data with defaults
+	expectedDefaults := []string{
+		`"education": []`,
+		`"work": []`,
+		"#26428b", // default accent color
+		`"font": "New Computer Modern"`,
+	}
+
+	for _, expected := range expectedDefaults {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Generated content missing expected default: %s", expected)
+		}
+	}
+}
+
+func TestGenerateBasicTypContentSanitization(t *testing.T) {
+	t.Parallel()
+	// Create form data with Typst-problematic content
+	formData := url.Values{
+		"name":     {"John \"The Great\" Doe"},
+		"email":    {"john@example.com#test"},
+		"github":   {"johndoe$repo"},
+		"location": {"New York & Associates"},
+	}
+
+	req := &http.Request{
+		Method: http.MethodPost,
+		Header: make(http.Header),
+		Form:   formData,
+	}
+
+	content := renderBasic(t, req)
+
+	// The template reads values as data, so they are kept as typed and only
+	// encoded as JSON
+	expectedEscaped := []string{
+		`"name": "John \"The Great\" Doe"`,
+		`"email": "john@example.com#test"`,
+		`"github": "https://johndoe$repo"`,
+		`"location": "New York \u0026 Associates"`,
+	}
+
+	for _, expected := range expectedEscaped {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Generated content missing expected escaped pattern: %s", expected)
+		}
+	}
+
+	// Should still contain safe parts
+	if !strings.Contains(content, "John") || !strings.Contains(content, "Doe") {
+		t.Error("Sanitization removed legitimate content")
+	}
+}
+
+func renderBasic(t *testing.T, r *http.Request) string {
+	t.Helper()
+	basic := renderer.Basic{}
+	files, err := basic.Render(decode(t, "basic", r), "")
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	return string(files[renderer.ViewFile])
+}
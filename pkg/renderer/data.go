@@ -0,0 +1,51 @@
+package renderer
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+)
+
+// DataFile is the file Data writes the CV to. Entry files of data templates
+// read it with `json("cv.json")`.
+const DataFile = "cv.json"
+
+// Data renders templates that have a manifest but no Go renderer. It writes
+// the CV model as JSON and leaves the layout entirely to the Typst entry file.
+type Data struct {
+	TemplateKey string
+	Meta        Metadata
+}
+
+func (d Data) Key() string { return d.TemplateKey }
+
+func (d Data) Metadata() Metadata { return d.Meta }
+
+func (Data) SourceFiles() []string { return nil }
+
+func (Data) Render(data *cv.CV, avatar string) (map[string][]byte, error) {
+	content, err := json.MarshalIndent(dataFile{CV: data, Avatar: avatar}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", DataFile, err)
+	}
+	return map[string][]byte{DataFile: content}, nil
+}
+
+// dataFile is the document written to DataFile.
+type dataFile struct {
+	*cv.CV
+	Avatar string `json:"avatar,omitempty"`
+}
+
+var builtins = map[string]TemplateRenderer{
+	"basic":   Basic{},
+	"modern":  Modern{},
+	"vantage": Vantage{},
+}
+
+// Builtin returns the Go renderer a manifest refers to by name.
+func Builtin(name string) (TemplateRenderer, bool) {
+	r, exists := builtins[name]
+	return r, exists
+}
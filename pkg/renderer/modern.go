@@ -0,0 +1,169 @@
+package renderer
+
+import (
+	"strings"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+	"github.com/AlexTLDR/mycv.quest/pkg/richtext"
+	"github.com/AlexTLDR/mycv.quest/pkg/typst"
+	"github.com/AlexTLDR/mycv.quest/pkg/utils"
+)
+
+// Modern renders the modern-resume template.
+type Modern struct{}
+
+func (Modern) Key() string { return "modern" }
+
+func (Modern) Metadata() Metadata {
+	return Metadata{
+		Name:        "Modern Resume",
+		Description: "Contemporary design with visual elements and photo support",
+		EntryFile:   "cv.typ",
+		NeedsPhoto:  true,
+		Font:        "Roboto",
+		Paper:       cv.PaperA4,
+		DateFormat:  cv.DateNumeric,
+	}
+}
+
+func (Modern) SourceFiles() []string { return []string{"config.yaml"} }
+
+func (Modern) Render(data *cv.CV, avatar string) (map[string][]byte, error) {
+	view, err := RenderModernData(data, avatar)
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{ViewFile: view, richtext.LibraryFile: richtext.Library}, nil
+}
+
+// RenderModernData renders a CV as the data file the modern template's cv.typ
+// reads. avatarFilename is empty when the CV has no photo.
+func RenderModernData(data *cv.CV, avatarFilename string) ([]byte, error) {
+	layout := resolveLayout(data, Modern{}.Metadata())
+
+	// Contacts keep their order, which the template lays out in two columns
+	contacts := []map[string]string{}
+	addContact := func(key, text, url string) {
+		if text != "" {
+			contacts = append(contacts, map[string]string{"key": key, "text": typst.Data(text), "url": typst.Data(url)})
+		}
+	}
+	addContact("email", data.Contact.Email, "mailto:"+data.Contact.Email)
+	addContact("mobile", data.Contact.Phone, "")
+	addContact("location", data.Contact.Location, "")
+	if data.Contact.LinkedIn.URL != "" {
+		url, label := linkedInLink(data.Contact.LinkedIn)
+		addContact("linkedin", label, url)
+	}
+	if data.Contact.GitHub.URL != "" {
+		url, label := webLink(data.Contact.GitHub)
+		addContact("github", label, url)
+	}
+	if data.Contact.Website.URL != "" {
+		url, label := webLink(data.Contact.Website)
+		addContact("website", label, url)
+	}
+
+	view := map[string]interface{}{
+		"author":    typst.Data(data.Person.Name),
+		"job_title": typst.Data(data.Person.Title),
+		"bio":       typst.Data(data.Person.Summary),
+		"avatar":    typst.Data(avatarFilename),
+		"font":      typst.Data(fontFamily(data, Modern{}.Metadata().Font)),
+		"contacts":  contacts,
+	}
+	layout.addTo(view)
+
+	education := []map[string]interface{}{}
+	for _, edu := range data.Education {
+		education = append(education, map[string]interface{}{
+			"title":       typst.Data(edu.Degree),
+			"subtitle":    typst.Data(edu.Institution),
+			"description": richText(edu.Description),
+			"dates":       layout.dateRange(edu.StartDate, edu.EndDate),
+		})
+	}
+	view["education"] = education
+
+	work := []map[string]interface{}{}
+	for _, job := range data.Experience {
+		work = append(work, map[string]interface{}{
+			"title":                typst.Data(job.Title),
+			"subtitle":             typst.Data(job.Company),
+			"facility_description": typst.Data(job.CompanyDescription),
+			"description":          richText(job.Description),
+			"dates":                layout.dateRange(job.StartDate, job.EndDate),
+		})
+	}
+	view["work"] = work
+
+	projects := []map[string]interface{}{}
+	for _, project := range data.Projects {
+		projects = append(projects, map[string]interface{}{
+			"title":       typst.Data(project.Name),
+			"subtitle":    typst.Data(project.Role),
+			"description": richText(project.Description),
+			"dates":       layout.dateRange(project.StartDate, project.EndDate),
+		})
+	}
+	view["projects"] = projects
+
+	certificates := []map[string]interface{}{}
+	for _, certificate := range data.Certificates {
+		certificates = append(certificates, map[string]interface{}{
+			"title":    typst.Data(certificate.Name),
+			"subtitle": typst.Data(certificate.Issuer),
+			"dates":    layout.dateRange(certificate.StartDate, certificate.EndDate),
+		})
+	}
+	view["certificates"] = certificates
+
+	languages := []string{}
+	for _, language := range data.Languages {
+		languages = append(languages, typst.Data(language.String()))
+	}
+	view["skills"] = dataList(data.SkillNames(skillCategories...))
+	view["languages"] = languages
+	view["interests"] = dataList(data.Interests)
+
+	return encodeView(view)
+}
+
+// skillCategories lists every category, since the modern template shows all
+// skills as one set of pills.
+var skillCategories = []string{
+	"", cv.CategoryProgramming, cv.CategoryTechnologies, cv.CategoryExpertise, cv.CategoryMethodology, cv.CategoryTools,
+}
+
+// dateRange returns the date-from and date-to arguments of an entry, leaving
+// out missing dates so the template's defaults apply.
+func (l pageLayout) dateRange(dateFrom, dateTo cv.Date) map[string]string {
+	dates := map[string]string{}
+	if !dateFrom.IsZero() {
+		dates["date-from"] = l.date(dateFrom)
+	}
+	if !dateTo.IsZero() {
+		dates["date-to"] = l.date(dateTo)
+	}
+	return dates
+}
+
+// linkedInLink expands a bare LinkedIn handle into a profile URL.
+func linkedInLink(link cv.Link) (string, string) {
+	if strings.Contains(link.URL, "linkedin.com") {
+		return webLink(link)
+	}
+	label := link.Label
+	if label == "" {
+		label = "linkedin/" + link.URL
+	}
+	return utils.LinkedInURL(link.URL), label
+}
+
+func webLink(link cv.Link) (string, string) {
+	label := link.Label
+	if label == "" {
+		label = link.URL
+	}
+	return utils.NormalizeURL(link.URL), label
+}
@@ -1,98 +1,19 @@
-package generator_test
+package renderer_test
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"testing"
 
-	"github.com/AlexTLDR/mycv.quest/pkg/config"
 	"github.com/AlexTLDR/mycv.quest/pkg/generator"
+	"github.com/AlexTLDR/mycv.quest/pkg/renderer"
 )
 
-func TestGenerateModernCV(t *testing.T) {
-	t.Parallel()
-	// Create test configuration
-	cfg := &config.Config{
-		Templates: map[string]config.Template{
-			"modern": {
-				Name:       "Modern Resume",
-				Dir:        "../../templates/modern/template",
-				InputFile:  "main.typ",
-				NeedsPhoto: true,
-			},
-		},
-		OutputDir: "test_output",
-	}
-
-	gen := generator.New(cfg)
-
-	// Create test form data
-	formData := url.Values{
-		"author":                         {"Alice Johnson"},
-		"job_title":                      {"Senior Software Engineer"},
-		"bio":                            {"Passionate developer with 5+ years of experience"},
-		"email":                          {"alice.johnson@example.com"},
-		"mobile":                         {"+1-555-444-3333"},
-		"location":                       {"Seattle, WA"},
-		"linkedin":                       {"alicejohnson"},
-		"github":                         {"alicejohnson"},
-		"website":                        {"https://alicejohnson.dev"},
-		"education[0][title]":            {"Master of Computer Science"},
-		"education[0][subtitle]":         {"University of Washington"},
-		"education[0][date_from]":        {"2018"},
-		"education[0][date_to]":          {"2020"},
-		"education[0][task_description]": {"Specialized in Machine Learning\nThesis on Neural Networks"},
-		"work[0][title]":                 {"Senior Software Engineer"},
-		"work[0][subtitle]":              {"Tech Innovations Inc."},
-		"work[0][facility_description]":  {"Leading technology company"},
-		"work[0][date_from]":             {"2020"},
-		"work[0][date_to]":               {"Present"},
-		"work[0][task_description]":      {"Lead development of cloud platforms\nManage team of 5 engineers"},
-		"skills":                         {"Go, Rust, Kubernetes, AWS, Machine Learning"},
-		"projects[0][title]":             {"Cloud Migration Platform"},
-		"projects[0][subtitle]":          {"Internal Tool"},
-		"projects[0][date_from]":         {"2023"},
-		"projects[0][date_to]":           {"2024"},
-		"projects[0][description]":       {"Automated cloud migration for legacy systems"},
-		"certificates[0][title]":         {"AWS Solutions Architect"},
-		"certificates[0][subtitle]":      {"Amazon Web Services"},
-		"certificates[0][date_from]":     {"2022"},
-		"certificates[0][date_to]":       {"2025"},
-		"languages":                      {"English, Spanish, French"},
-		"interests":                      {"Rock climbing, Photography, Open source"},
-	}
-
-	// Create HTTP request with form data
-	req := &http.Request{
-		Method: http.MethodPost,
-		Header: make(http.Header),
-		Form:   formData,
-	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	// Generate CV
-	pdfData, err := gen.GenerateModernCV(cfg.Templates["modern"], req)
-	if err != nil {
-		t.Fatalf("Failed to generate modern CV: %v", err)
-	}
-
-	// Verify PDF data is not empty
-	if len(pdfData) == 0 {
-		t.Fatal("Generated PDF data is empty")
-	}
-
-	// Verify PDF header (PDF files start with %PDF-)
-	if !strings.HasPrefix(string(pdfData[:4]), "%PDF") {
-		t.Fatal("Generated data is not a valid PDF file")
-	}
-}
-
 func TestGenerateModernTypContent(t *testing.T) {
 	t.Parallel()
-	cfg := &config.Config{}
-	gen := generator.New(cfg)
-
 	// Create test form data
 	formData := url.Values{
 		"author":                         {"Bob Wilson"},
@@ -132,25 +53,24 @@ func TestGenerateModernTypContent(t *testing.T) {
 	}
 
 	avatarFilename := generator.DefaultAvatarFilename
-	content := gen.GenerateModernTypContent(req, avatarFilename)
+	content := renderModern(t, req, avatarFilename)
 
 	// Test that content includes expected sections and data
 	expectedSections := []string{
-		"#import \"@preview/modern-resume:0.1.0\": modern-resume",
-		"== Education",
-		"== Work experience",
-		"== Skills",
-		"== Projects",
-		"== Certificates",
-		"== Languages",
-		"== Interests",
+		`"education": [`,
+		`"work": [`,
+		`"skills": [`,
+		`"projects": [`,
+		`"certificates": []`,
+		`"languages": [`,
+		`"interests": [`,
 		"Bob Wilson",
 		"DevOps Engineer",
 		"bob.wilson@example.com",
 		"Colorado State University",
 		"CloudTech Solutions",
 		"Infrastructure as Code",
-		"image(\"avatar.png\")",
+		`"avatar": "avatar.png"`,
 	}
 
 	for _, expected := range expectedSections {
@@ -172,9 +92,6 @@ func TestGenerateModernTypContent(t *testing.T) {
 
 func TestGenerateModernTypContentMinimal(t *testing.T) {
 	t.Parallel()
-	cfg := &config.Config{}
-	gen := generator.New(cfg)
-
 	// Create minimal form data - only required fields
 	formData := url.Values{
 		"author":    {"Minimal User"},
@@ -190,18 +107,17 @@ func TestGenerateModernTypContentMinimal(t *testing.T) {
 	}
 
 	avatarFilename := "default.png"
-	content := gen.GenerateModernTypContent(req, avatarFilename)
+	content := renderModern(t, req, avatarFilename)
 
-	// Should still generate valid typst content with minimal data
+	// Should still generate valid data with minimal data
 	expectedElements := []string{
-		"#import \"@preview/modern-resume:0.1.0\": modern-resume",
 		"Minimal User",
 		"Developer",
 		"Simple bio",
 		"minimal@example.com",
-		"image(\"default.png\")",
-		"== Education",
-		"== Work experience",
+		`"avatar": "default.png"`,
+		`"education": []`,
+		`"work": []`,
 	}
 
 	for _, expected := range expectedElements {
@@ -213,9 +129,6 @@ func TestGenerateModernTypContentMinimal(t *testing.T) {
 
 func TestGenerateModernTypContentSanitization(t *testing.T) {
 	t.Parallel()
-	cfg := &config.Config{}
-	gen := generator.New(cfg)
-
 	// Create form data with Typst-problematic content
 	formData := url.Values{
 		"author":    {"Alice \"The Engineer\" Doe"},
@@ -233,19 +146,19 @@ func TestGenerateModernTypContentSanitization(t *testing.T) {
 	}
 
 	avatarFilename := generator.DefaultAvatarFilename
-	content := gen.GenerateModernTypContent(req, avatarFilename)
+	content := renderModern(t, req, avatarFilename)
 
-	// Check that Typst special characters are properly escaped
-	expectedEscaped := []string{
-		"\\\"", // Quotes should be escaped
-		"\\#",  // Hash should be escaped (Typst function marker)
-		"\\$",  // Dollar should be escaped (Typst math mode)
+	// The template reads values as data, so Typst markers are kept as typed
+	var view struct {
+		Author   string `json:"author"`
+		JobTitle string `json:"job_title"`
+		Bio      string `json:"bio"`
 	}
-
-	for _, expected := range expectedEscaped {
-		if !strings.Contains(content, expected) {
-			t.Errorf("Generated content missing expected escaped pattern: %s", expected)
-		}
+	if err := json.Unmarshal([]byte(content), &view); err != nil {
+		t.Fatalf("Generated data is not JSON: %v", err)
+	}
+	if view.Author != `Alice "The Engineer" Doe` || view.JobTitle != "Senior Engineer#Lead" || view.Bio != "Bio with $math and #functions" {
+		t.Errorf("Values should be kept as typed: %+v", view)
 	}
 
 	// Should still contain safe parts
@@ -256,9 +169,6 @@ func TestGenerateModernTypContentSanitization(t *testing.T) {
 
 func TestGenerateModernTypContentSkillsParsing(t *testing.T) {
 	t.Parallel()
-	cfg := &config.Config{}
-	gen := generator.New(cfg)
-
 	// Test skills parsing with various formats
 	formData := url.Values{
 		"author":    {"Test User"},
@@ -277,9 +187,17 @@ func TestGenerateModernTypContentSkillsParsing(t *testing.T) {
 	}
 
 	avatarFilename := generator.DefaultAvatarFilename
-	content := gen.GenerateModernTypContent(req, avatarFilename)
+	content := renderModern(t, req, avatarFilename)
+
+	var view struct {
+		Skills    []string `json:"skills"`
+		Languages []string `json:"languages"`
+	}
+	if err := json.Unmarshal([]byte(content), &view); err != nil {
+		t.Fatalf("Generated data is not JSON: %v", err)
+	}
 
-	// Check that skills are properly parsed and formatted as pills
+	// Check that skills are properly parsed into the pill lists
 	expectedSkills := []string{
 		"Go",
 		"React",
@@ -289,8 +207,7 @@ func TestGenerateModernTypContentSkillsParsing(t *testing.T) {
 	}
 
 	for _, skill := range expectedSkills {
-		skillPattern := "#pill(\"" + skill + "\", fill: true)"
-		if !strings.Contains(content, skillPattern) {
+		if !slices.Contains(view.Skills, skill) {
 			t.Errorf("Generated content missing expected skill pill for: %s", skill)
 		}
 	}
@@ -303,9 +220,18 @@ func TestGenerateModernTypContentSkillsParsing(t *testing.T) {
 	}
 
 	for _, lang := range expectedLanguages {
-		langPattern := "#pill(\"" + lang + "\")"
-		if !strings.Contains(content, langPattern) {
+		if !slices.Contains(view.Languages, lang) {
 			t.Errorf("Generated content missing expected language pill for: %s", lang)
 		}
 	}
 }
+
+func renderModern(t *testing.T, r *http.Request, avatar string) string {
+	t.Helper()
+	modern := renderer.Modern{}
+	files, err := modern.Render(decode(t, "modern", r), avatar)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	return string(files[renderer.ViewFile])
+}
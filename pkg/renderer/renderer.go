@@ -0,0 +1,131 @@
+// Package renderer turns the CV model into the data files each template's
+// checked-in Typst entry file reads. Every template implements
+// TemplateRenderer; template manifests pick one by name, and templates without
+// a Go renderer use Data.
+package renderer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+	"github.com/AlexTLDR/mycv.quest/pkg/richtext"
+	"github.com/AlexTLDR/mycv.quest/pkg/typst"
+)
+
+// Metadata holds a renderer's defaults. A template's manifest overrides them.
+type Metadata struct {
+	Name        string
+	Description string
+	// EntryFile is the Typst file compiled in the work directory.
+	EntryFile  string
+	NeedsPhoto bool
+	// Font is the family the template sets when the CV names none.
+	Font string
+	// Paper is the paper size the template sets when the CV names none.
+	Paper string
+	// DateFormat is the cv date format used when the CV names none.
+	DateFormat string
+}
+
+// TemplateRenderer is implemented once per Typst template.
+type TemplateRenderer interface {
+	// Key is the template's identifier in URLs and on the CLI.
+	Key() string
+	Metadata() Metadata
+	// SourceFiles lists the files and directories copied from the template
+	// directory into the work directory along with the entry file.
+	SourceFiles() []string
+	// Render returns the generated files, keyed by their path in the work
+	// directory. avatar is the avatar filename, or empty if there is none.
+	Render(data *cv.CV, avatar string) (map[string][]byte, error)
+}
+
+// ViewFile is the file the Go renderers write a template's data to. Their
+// checked-in entry files read it with `json("data.json")`, so the CV is only
+// ever displayed and never becomes Typst code.
+const ViewFile = "data.json"
+
+// marginsMM are the page margins of the margin presets, in millimetres.
+var marginsMM = map[string]int{
+	cv.MarginsNarrow: 12,
+	cv.MarginsNormal: 20,
+	cv.MarginsWide:   28,
+}
+
+// pageLayout is a CV's layout resolved against a template's defaults.
+type pageLayout struct {
+	Paper string
+	// MarginMM is zero when the template keeps its own margins.
+	MarginMM int
+	Lang     string
+	// Region is empty when the CV names none.
+	Region     string
+	DateFormat string
+}
+
+// resolveLayout returns the page setup of a CV. Lang defaults to English.
+func resolveLayout(data *cv.CV, meta Metadata) pageLayout {
+	return pageLayout{
+		Paper:      firstNonEmpty(data.Layout.Paper, meta.Paper),
+		MarginMM:   marginsMM[data.Layout.Margins],
+		Lang:       firstNonEmpty(strings.ToLower(data.Layout.Lang), "en"),
+		Region:     strings.ToUpper(data.Layout.Region),
+		DateFormat: firstNonEmpty(data.Layout.DateFormat, meta.DateFormat),
+	}
+}
+
+// addTo writes the page setup to a template's data. Margins and region are
+// left out when the template keeps its own.
+func (l pageLayout) addTo(view map[string]interface{}) {
+	view["paper"] = typst.Data(l.Paper)
+	view["lang"] = typst.Data(l.Lang)
+	if l.MarginMM != 0 {
+		view["margin_mm"] = l.MarginMM
+	}
+	if l.Region != "" {
+		view["region"] = typst.Data(l.Region)
+	}
+}
+
+// date formats a date for the CV's language.
+func (l pageLayout) date(date cv.Date) string {
+	return date.Format(l.DateFormat, l.Lang)
+}
+
+// richText parses a description for a template's data. Templates render it
+// with the functions of richtext.Library.
+func richText(description string) []richtext.Node {
+	nodes := richtext.Tree(typst.Data(description))
+	if nodes == nil {
+		return []richtext.Node{}
+	}
+	return nodes
+}
+
+// encodeView encodes a template's data as ViewFile.
+func encodeView(view map[string]interface{}) ([]byte, error) {
+	content, err := json.MarshalIndent(view, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", ViewFile, err)
+	}
+	return content, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// fontFamily returns the font the CV asks for, or fallback.
+func fontFamily(data *cv.CV, fallback string) string {
+	if data.Theme.Font != "" {
+		return data.Theme.Font
+	}
+	return fallback
+}
@@ -0,0 +1,254 @@
+package renderer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/config"
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+	"github.com/AlexTLDR/mycv.quest/pkg/generator"
+	"github.com/AlexTLDR/mycv.quest/pkg/renderer"
+	"github.com/AlexTLDR/mycv.quest/pkg/richtext"
+	"github.com/AlexTLDR/mycv.quest/pkg/schema"
+)
+
+func TestSwitchTemplates(t *testing.T) {
+	t.Parallel()
+	formData := url.Values{
+		"name":                      {"Jane Smith"},
+		"email":                     {"jane.smith@example.com"},
+		"education[0][institution]": {"State University"},
+		"education[0][degree]":      {"Master of Science"},
+		"work[0][title]":            {"Senior Developer"},
+		"work[0][company]":          {"Innovation Labs"},
+		"work[0][description]":      {"- Led the platform team\n- Cut costs by 30%"},
+		"programming_languages":     {"Rust, Go"},
+	}
+
+	req := &http.Request{
+		Method: http.MethodPost,
+		Header: make(http.Header),
+		Form:   formData,
+	}
+
+	data := decode(t, "basic", req)
+
+	modern := modernData(t, data, generator.DefaultAvatarFilename)
+	for _, expected := range []string{
+		`"author": "Jane Smith"`,
+		`"title": "Master of Science"`,
+		`"subtitle": "State University"`,
+		`"subtitle": "Innovation Labs"`,
+		`"text": "Led the platform team"`,
+		`"avatar": "` + generator.DefaultAvatarFilename + `"`,
+		`"Rust"`,
+	} {
+		if !strings.Contains(modern, expected) {
+			t.Errorf("Modern content missing %q", expected)
+		}
+	}
+
+	vantage := string(renderer.RenderVantageYAML(data))
+	for _, expected := range []string{
+		"name: Jane Smith",
+		"position: Senior Developer",
+		"text: Cut costs by 30%",
+		"- Rust",
+	} {
+		if !strings.Contains(vantage, expected) {
+			t.Errorf("Vantage YAML missing %q", expected)
+		}
+	}
+}
+
+func TestRenderFont(t *testing.T) {
+	t.Parallel()
+	req := &http.Request{
+		Method: http.MethodPost,
+		Header: make(http.Header),
+		Form:   url.Values{"name": {"Jane Smith"}, "font": {"Libertinus Serif"}},
+	}
+	data := decode(t, "basic", req)
+
+	if basic := basicData(t, data); !strings.Contains(basic, `"font": "Libertinus Serif"`) {
+		t.Error("Basic content does not set the chosen font")
+	}
+	if modern := modernData(t, data, ""); !strings.Contains(modern, `"font": "Libertinus Serif"`) {
+		t.Error("Modern content does not set the chosen font")
+	}
+	if vantage := string(renderer.RenderVantageYAML(data)); !strings.Contains(vantage, "font: Libertinus Serif") {
+		t.Error("Vantage YAML does not set the chosen font")
+	}
+
+	// Without a choice each template keeps its own font
+	empty := &cv.CV{}
+	if modern := modernData(t, empty, ""); !strings.Contains(modern, `"font": "Roboto"`) {
+		t.Error("Modern content does not default to Roboto")
+	}
+	if vantage := string(renderer.RenderVantageYAML(empty)); !strings.Contains(vantage, "font: PT Sans") {
+		t.Error("Vantage YAML does not default to PT Sans")
+	}
+}
+
+func TestRenderLayout(t *testing.T) {
+	t.Parallel()
+	req := &http.Request{
+		Method: http.MethodPost,
+		Header: make(http.Header),
+		Form: url.Values{
+			"name":    {"Jane Smith"},
+			"paper":   {"a4"},
+			"margins": {"wide"},
+			"lang":    {"de"},
+			"region":  {"AT"},
+		},
+	}
+	data := decode(t, "basic", req)
+
+	for name, content := range map[string]string{
+		"Basic":  basicData(t, data),
+		"Modern": modernData(t, data, ""),
+	} {
+		for _, expected := range []string{`"paper": "a4"`, `"margin_mm": 28`, `"lang": "de"`, `"region": "AT"`} {
+			if !strings.Contains(content, expected) {
+				t.Errorf("%s content missing %q", name, expected)
+			}
+		}
+	}
+	vantage := string(renderer.RenderVantageYAML(data))
+	for _, expected := range []string{"paper: a4", "margin_mm: 28", "lang: de", "region: AT"} {
+		if !strings.Contains(vantage, expected) {
+			t.Errorf("Vantage YAML missing %q", expected)
+		}
+	}
+
+	// Without a layout each template keeps its own page setup
+	basic := basicData(t, &cv.CV{})
+	for _, expected := range []string{`"paper": "us-letter"`, `"lang": "en"`} {
+		if !strings.Contains(basic, expected) {
+			t.Errorf("Basic data missing default %q", expected)
+		}
+	}
+	if strings.Contains(basic, "margin_mm") || strings.Contains(basic, "region") {
+		t.Errorf("Basic data should leave margins and region to the template:\n%s", basic)
+	}
+	if vantage := string(renderer.RenderVantageYAML(&cv.CV{})); strings.Contains(vantage, "margin_mm") || strings.Contains(vantage, "region") {
+		t.Errorf("Vantage YAML should leave margins and region to the template:\n%s", vantage)
+	}
+}
+
+func TestRenderDates(t *testing.T) {
+	t.Parallel()
+	req := &http.Request{
+		Method: http.MethodPost,
+		Header: make(http.Header),
+		Form: url.Values{
+			"name":                {"Jane Smith"},
+			"lang":                {"de"},
+			"date_format":         {"long"},
+			"work[0][title]":      {"Intern"},
+			"work[0][start_date]": {"2019-03"},
+			"work[0][end_date]":   {"Oct 2019"},
+			"work[1][title]":      {"Engineer"},
+			"work[1][start_date]": {"March 2020"},
+			"work[1][end_date]":   {"Present"},
+		},
+	}
+	data := decode(t, "basic", req).SortedByDate()
+
+	basic := basicData(t, data)
+	if !strings.Contains(basic, `"from": "März 2020"`) || !strings.Contains(basic, `"to": "heute"`) {
+		t.Errorf("Basic data does not format dates in German:\n%s", basic)
+	}
+	if strings.Index(basic, "Engineer") > strings.Index(basic, "Intern") {
+		t.Error("Basic data should list the current job first")
+	}
+
+	data.Layout = cv.Layout{}
+	if modern := modernData(t, data, ""); !strings.Contains(modern, `"date-from": "03/2020"`) || !strings.Contains(modern, `"date-to": "Present"`) {
+		t.Errorf("Modern data should default to numeric English dates:\n%s", modern)
+	}
+	if vantage := string(renderer.RenderVantageYAML(data)); !strings.Contains(vantage, "from: Mar 2019") {
+		t.Errorf("Vantage YAML should default to short dates:\n%s", vantage)
+	}
+}
+
+func TestRenderRichText(t *testing.T) {
+	t.Parallel()
+	data := &cv.CV{
+		Experience: []cv.Experience{{
+			Title:       "Engineer",
+			Description: "Cut **latency** by 40%\n  - rewrote the `cache` in *Go*\nSee [write-up](https://blog.dev/post) #hashtag",
+		}},
+	}
+
+	want, err := json.Marshal(richtext.Tree(data.Experience[0].Description))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	for name, content := range map[string]string{
+		"Basic":  basicData(t, data),
+		"Modern": modernData(t, data, ""),
+	} {
+		var view struct {
+			Work []struct {
+				Description json.RawMessage `json:"description"`
+			} `json:"work"`
+		}
+		if err := json.Unmarshal([]byte(content), &view); err != nil {
+			t.Fatalf("%s data is not JSON: %v", name, err)
+		}
+		var got bytes.Buffer
+		if err := json.Compact(&got, view.Work[0].Description); err != nil {
+			t.Fatalf("Compact failed: %v", err)
+		}
+		if got.String() != string(want) {
+			t.Errorf("%s description = %s, want %s", name, got.String(), want)
+		}
+	}
+
+	vantage := string(renderer.RenderVantageYAML(data))
+	for _, expected := range []string{"kind: strong", "url: https://blog.dev/post", "text: ' #hashtag'"} {
+		if !strings.Contains(vantage, expected) {
+			t.Errorf("Vantage YAML missing %q:\n%s", expected, vantage)
+		}
+	}
+}
+
+// basicData renders the basic template's data file.
+func basicData(t *testing.T, data *cv.CV) string {
+	t.Helper()
+	view, err := renderer.RenderBasicData(data)
+	if err != nil {
+		t.Fatalf("RenderBasicData failed: %v", err)
+	}
+	return string(view)
+}
+
+// modernData renders the modern template's data file.
+func modernData(t *testing.T, data *cv.CV, avatar string) string {
+	t.Helper()
+	view, err := renderer.RenderModernData(data, avatar)
+	if err != nil {
+		t.Fatalf("RenderModernData failed: %v", err)
+	}
+	return string(view)
+}
+
+// decode reads a submitted form with the field schema of a bundled template.
+func decode(t *testing.T, key string, r *http.Request) *cv.CV {
+	t.Helper()
+	cfg, err := config.NewConfig("../../templates")
+	if err != nil {
+		t.Fatalf("NewConfig failed: %v", err)
+	}
+	template, exists := cfg.GetTemplate(key)
+	if !exists {
+		t.Fatalf("Template %s not found", key)
+	}
+	return schema.Decode(template.Fields, r.Form)
+}
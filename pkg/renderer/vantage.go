@@ -0,0 +1,145 @@
+package renderer
+
+import (
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+	"github.com/AlexTLDR/mycv.quest/pkg/richtext"
+	"github.com/AlexTLDR/mycv.quest/pkg/typst"
+	"github.com/AlexTLDR/mycv.quest/pkg/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// Vantage renders the vantage template, which reads its data from
+// configuration.yaml.
+type Vantage struct{}
+
+func (Vantage) Key() string { return "vantage" }
+
+func (Vantage) Metadata() Metadata {
+	return Metadata{
+		Name:        "Vantage",
+		Description: "Clean and professional design with modern typography",
+		EntryFile:   "example.typ",
+		Font:        "PT Sans",
+		Paper:       cv.PaperA4,
+		DateFormat:  cv.DateShort,
+	}
+}
+
+func (Vantage) SourceFiles() []string {
+	return []string{"vantage-typst.typ", "icons"}
+}
+
+func (Vantage) Render(data *cv.CV, _ string) (map[string][]byte, error) {
+	return map[string][]byte{"configuration.yaml": RenderVantageYAML(data), richtext.LibraryFile: richtext.Library}, nil
+}
+
+// RenderVantageYAML renders a CV as the vantage template's configuration.yaml.
+func RenderVantageYAML(data *cv.CV) []byte {
+	config := map[string]interface{}{
+		"contacts": map[string]interface{}{
+			"name":    typst.Data(data.Person.Name),
+			"title":   typst.Data(data.Person.Title),
+			"email":   typst.Data(data.Contact.Email),
+			"phone":   typst.Data(data.Contact.Phone),
+			"address": typst.Data(data.Contact.Location),
+			"linkedin": map[string]string{
+				"url":         typst.Data(utils.NormalizeURL(data.Contact.LinkedIn.URL)),
+				"displayText": typst.Data(data.Contact.LinkedIn.Label),
+			},
+			"github": map[string]string{
+				"url":         typst.Data(utils.NormalizeURL(data.Contact.GitHub.URL)),
+				"displayText": typst.Data(data.Contact.GitHub.Label),
+			},
+			"website": map[string]string{
+				"url":         typst.Data(utils.NormalizeURL(data.Contact.Website.URL)),
+				"displayText": typst.Data(data.Contact.Website.Label),
+			},
+		},
+		"position":  typst.Data(data.Person.Position),
+		"tagline":   typst.Data(data.Person.Summary),
+		"objective": typst.Data(data.Person.Objective),
+		"font":      typst.Data(fontFamily(data, Vantage{}.Metadata().Font)),
+	}
+
+	layout := resolveLayout(data, Vantage{}.Metadata())
+	layout.addTo(config)
+
+	jobs := []map[string]interface{}{}
+	for _, work := range data.Experience {
+		job := map[string]interface{}{
+			"position": typst.Data(work.Title),
+			"company": map[string]string{
+				"name": typst.Data(work.Company),
+				"link": typst.Data(utils.NormalizeURL(work.CompanyURL)),
+			},
+			"product": map[string]string{
+				"name": typst.Data(work.Product),
+				"link": typst.Data(utils.NormalizeURL(work.ProductURL)),
+			},
+			"from":        typst.Data(layout.date(work.StartDate)),
+			"to":          typst.Data(layout.date(work.EndDate)),
+			"location":    typst.Data(work.Location),
+			"description": richText(work.Description),
+		}
+		jobs = append(jobs, job)
+	}
+	config["jobs"] = jobs
+
+	education := []map[string]interface{}{}
+	for _, edu := range data.Education {
+		education = append(education, map[string]interface{}{
+			"place": map[string]string{
+				"name": typst.Data(edu.Institution),
+				"link": typst.Data(utils.NormalizeURL(edu.InstitutionURL)),
+			},
+			"degree":   typst.Data(edu.Degree),
+			"major":    typst.Data(edu.Major),
+			"track":    typst.Data(edu.Track),
+			"from":     typst.Data(layout.date(edu.StartDate)),
+			"to":       typst.Data(layout.date(edu.EndDate)),
+			"location": typst.Data(edu.Location),
+		})
+	}
+	config["education"] = education
+
+	technicalExpertise := []map[string]interface{}{}
+	for _, skill := range data.Skills {
+		if skill.Category != cv.CategoryExpertise {
+			continue
+		}
+		level := skill.Level
+		if level == 0 {
+			level = 4 // default
+		}
+		technicalExpertise = append(technicalExpertise, map[string]interface{}{
+			"name":  typst.Data(skill.Name),
+			"level": level,
+		})
+	}
+	config["technical_expertise"] = technicalExpertise
+
+	achievements := []map[string]interface{}{}
+	for _, achievement := range data.Achievements {
+		achievements = append(achievements, map[string]interface{}{
+			"name":        typst.Data(achievement.Name),
+			"description": richText(achievement.Description),
+		})
+	}
+	config["achievements"] = achievements
+
+	config["skills"] = dataList(data.SkillNames("", cv.CategoryProgramming, cv.CategoryTechnologies))
+	config["methodology"] = dataList(data.SkillNames(cv.CategoryMethodology))
+	config["tools"] = dataList(data.SkillNames(cv.CategoryTools))
+
+	yamlData, _ := yaml.Marshal(config)
+	return yamlData
+}
+
+// dataList cleans each item of a list for the YAML data file.
+func dataList(items []string) []string {
+	cleaned := []string{}
+	for _, item := range items {
+		cleaned = append(cleaned, typst.Data(item))
+	}
+	return cleaned
+}
@@ -1,4 +1,4 @@
-package generator_test
+package renderer_test
 
 import (
 	"net/http"
@@ -6,102 +6,12 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/AlexTLDR/mycv.quest/pkg/config"
-	"github.com/AlexTLDR/mycv.quest/pkg/generator"
+	"github.com/AlexTLDR/mycv.quest/pkg/renderer"
 	"gopkg.in/yaml.v2"
 )
 
-func TestGenerateVantageCV(t *testing.T) {
-	t.Parallel()
-	// Create test configuration
-	cfg := &config.Config{
-		Templates: map[string]config.Template{
-			"vantage": {
-				Name:      "Vantage Resume",
-				Dir:       "../../templates/vantage",
-				InputFile: "example.typ",
-			},
-		},
-		OutputDir: "test_output",
-	}
-
-	gen := generator.New(cfg)
-
-	// Create test form data
-	formData := url.Values{
-		"name":                  {"Charlie Brown"},
-		"title":                 {"Full Stack Developer"},
-		"email":                 {"charlie.brown@example.com"},
-		"address":               {"123 Main St"},
-		"location":              {"Portland, OR"},
-		"linkedin_url":          {"https://linkedin.com/in/charliebrown"},
-		"linkedin_display_text": {"linkedin.com/in/charliebrown"},
-		"github_url":            {"https://github.com/charliebrown"},
-		"github_display_text":   {"github.com/charliebrown"},
-		"website_url":           {"https://charliebrown.dev"},
-		"website_display_text":  {"charliebrown.dev"},
-		"position":              {"Senior Developer"},
-		"tagline":               {"Building scalable web applications"},
-		"objective":             {"Seeking challenging opportunities in full-stack development"},
-		"jobs[0][position]":     {"Software Engineer"},
-		"jobs[0][company_name]": {"Acme Corp"},
-		"jobs[0][company_link]": {"https://acme.com"},
-		"jobs[0][product_name]": {"E-commerce Platform"},
-		"jobs[0][product_link]": {"https://acme.com/platform"},
-		"jobs[0][from]":         {"2022"},
-		"jobs[0][to]":           {"Present"},
-		"jobs[0][location]":     {"Remote"},
-		"jobs[0][description]":  {"Developed microservices architecture\nImplemented REST APIs\nOptimized database performance"},
-
-		"education[0][place_name]":      {"Oregon State University"},
-		"education[0][place_link]":      {"https://oregonstate.edu"},
-		"education[0][degree]":          {"Bachelor of Science"},
-		"education[0][major]":           {"Computer Science"},
-		"education[0][track]":           {"Software Engineering"},
-		"education[0][from]":            {"2018"},
-		"education[0][to]":              {"2022"},
-		"education[0][location]":        {"Corvallis, OR"},
-		"technical_expertise[0][name]":  {"Go"},
-		"technical_expertise[0][level]": {"5"},
-		"technical_expertise[1][name]":  {"React"},
-		"technical_expertise[1][level]": {"4"},
-		"achievements[0][name]":         {"Employee of the Year"},
-		"achievements[0][description]":  {"Recognized for outstanding contribution to platform development"},
-		"skills":                        {"Go, React, PostgreSQL, Docker"},
-		"methodology":                   {"Agile, Scrum, TDD"},
-		"tools":                         {"Git, Docker, Kubernetes, AWS"},
-	}
-
-	// Create HTTP request with form data
-	req := &http.Request{
-		Method: http.MethodPost,
-		Header: make(http.Header),
-		Form:   formData,
-	}
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	// Generate CV
-	pdfData, err := gen.GenerateVantageCV(cfg.Templates["vantage"], req)
-	if err != nil {
-		t.Fatalf("Failed to generate vantage CV: %v", err)
-	}
-
-	// Verify PDF data is not empty
-	if len(pdfData) == 0 {
-		t.Fatal("Generated PDF data is empty")
-	}
-
-	// Verify PDF header (PDF files start with %PDF-)
-	if !strings.HasPrefix(string(pdfData[:4]), "%PDF") {
-		t.Fatal("Generated data is not a valid PDF file")
-	}
-}
-
 func TestGenerateVantageYAMLContent(t *testing.T) {
 	t.Parallel()
-	cfg := &config.Config{}
-	gen := generator.New(cfg)
-
 	// Create comprehensive test form data
 	formData := url.Values{
 		"name":                  {"Diana Prince"},
@@ -167,7 +77,7 @@ func TestGenerateVantageYAMLContent(t *testing.T) {
 		Form:   formData,
 	}
 
-	yamlContent := gen.GenerateVantageYAMLContent(req)
+	yamlContent := renderVantage(t, req)
 
 	// Parse the generated YAML to verify structure
 	var data map[string]interface{}
@@ -291,9 +201,6 @@ func TestGenerateVantageYAMLContent(t *testing.T) {
 
 func TestGenerateVantageYAMLContentMinimal(t *testing.T) {
 	t.Parallel()
-	cfg := &config.Config{}
-	gen := generator.New(cfg)
-
 	// Create minimal form data
 	formData := url.Values{
 		"name":     {"John Minimal"},
@@ -308,7 +215,7 @@ func TestGenerateVantageYAMLContentMinimal(t *testing.T) {
 		Form:   formData,
 	}
 
-	yamlContent := gen.GenerateVantageYAMLContent(req)
+	yamlContent := renderVantage(t, req)
 
 	// Parse the generated YAML
 	var data map[string]interface{}
@@ -341,9 +248,6 @@ func TestGenerateVantageYAMLContentMinimal(t *testing.T) {
 
 func TestGenerateVantageYAMLContentSanitization(t *testing.T) {
 	t.Parallel()
-	cfg := &config.Config{}
-	gen := generator.New(cfg)
-
 	// Create form data with Typst-problematic content
 	formData := url.Values{
 		"name":                  {"John \"The Developer\" Smith"},
@@ -362,7 +266,7 @@ func TestGenerateVantageYAMLContentSanitization(t *testing.T) {
 		Form:   formData,
 	}
 
-	yamlContent := gen.GenerateVantageYAMLContent(req)
+	yamlContent := renderVantage(t, req)
 
 	// Parse the YAML - it should still be valid
 	var data map[string]interface{}
@@ -374,17 +278,16 @@ func TestGenerateVantageYAMLContentSanitization(t *testing.T) {
 	// Convert back to string to check content
 	yamlString := string(yamlContent)
 
-	// Check that Typst special characters are properly escaped in YAML values
-	expectedEscaped := []string{
-		"\\\"", // Quotes should be escaped
-		"\\#",  // Hash should be escaped (Typst function marker)
-		"\\$",  // Dollar should be escaped (Typst math mode)
+	// The template displays YAML values as typed, so they are not escaped
+	contacts, ok := data["contacts"].(map[interface{}]interface{})
+	if !ok || contacts["name"] != `John "The Developer" Smith` {
+		t.Errorf("Name should be kept as typed: %v", contacts["name"])
 	}
 
-	for _, expected := range expectedEscaped {
-		if !strings.Contains(yamlString, expected) {
-			t.Errorf("Generated YAML missing expected escaped pattern: %s", expected)
-		}
+	// Descriptions are rich text the template renders as data, so they are
+	// kept as typed too
+	if !strings.Contains(yamlString, "text: 'Used $tech and #functions'") {
+		t.Errorf("Description should be kept as typed:\n%s", yamlString)
 	}
 
 	// Check URL normalization
@@ -400,9 +303,6 @@ func TestGenerateVantageYAMLContentSanitization(t *testing.T) {
 
 func TestGenerateVantageYAMLContentTechnicalExpertiseLevels(t *testing.T) {
 	t.Parallel()
-	cfg := &config.Config{}
-	gen := generator.New(cfg)
-
 	// Test different level values including edge cases
 	formData := url.Values{
 		"name":                          {"Test User"},
@@ -423,7 +323,7 @@ func TestGenerateVantageYAMLContentTechnicalExpertiseLevels(t *testing.T) {
 		Form:   formData,
 	}
 
-	yamlContent := gen.GenerateVantageYAMLContent(req)
+	yamlContent := renderVantage(t, req)
 
 	var data map[string]interface{}
 	err := yaml.Unmarshal(yamlContent, &data)
@@ -472,3 +372,13 @@ func TestGenerateVantageYAMLContentTechnicalExpertiseLevels(t *testing.T) {
 		}
 	}
 }
+
+func renderVantage(t *testing.T, r *http.Request) []byte {
+	t.Helper()
+	vantage := renderer.Vantage{}
+	files, err := vantage.Render(decode(t, "vantage", r), "")
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	return files["configuration.yaml"]
+}
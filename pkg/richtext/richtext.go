@@ -0,0 +1,289 @@
+// Package richtext parses the small Markdown-like markup CV descriptions may
+// use: **bold**, *italics* or _italics_, `inline code`, [links](https://...)
+// and bullets nested by indentation. Everything else is plain text.
+//
+// Templates receive the parsed text as data and render it with the functions
+// of Library, so user input never becomes Typst code.
+package richtext
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// LibraryFile is the name templates import Library under.
+const LibraryFile = "richtext.typ"
+
+// Library is the Typst module that renders parsed rich text. Its bullets
+// function renders the nodes of Tree as a list, and spans renders a line.
+//
+//go:embed richtext.typ
+var Library []byte
+
+// Kind is the kind of an inline span. It is written to data files by name.
+type Kind int
+
+const (
+	// Text is plain text.
+	Text Kind = iota
+	// Strong is bold text made of child spans.
+	Strong
+	// Emph is italic text made of child spans.
+	Emph
+	// Code is inline code, kept verbatim in Text.
+	Code
+	// Link is a hyperlink to URL labelled by child spans.
+	Link
+)
+
+// kindNames are the names of the kinds in data files.
+var kindNames = []string{Text: "text", Strong: "strong", Emph: "emph", Code: "code", Link: "link"}
+
+// MarshalText returns the name of the kind.
+func (k Kind) MarshalText() ([]byte, error) {
+	if k < 0 || int(k) >= len(kindNames) {
+		return nil, fmt.Errorf("unknown span kind %d", k)
+	}
+	return []byte(kindNames[k]), nil
+}
+
+// Span is a run of inline content.
+type Span struct {
+	Kind     Kind   `json:"kind" yaml:"kind"`
+	Text     string `json:"text,omitempty" yaml:"text,omitempty"`
+	URL      string `json:"url,omitempty" yaml:"url,omitempty"`
+	Children []Span `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// Item is one line of a description, nested Level bullets deep.
+type Item struct {
+	Level int
+	Spans []Span
+}
+
+// Node is one line of a description with the lines nested under it.
+type Node struct {
+	Spans    []Span `json:"spans" yaml:"spans"`
+	Children []Node `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// tabWidth is the number of spaces a tab counts for in indentation.
+const tabWidth = 4
+
+// bulletMarkers are stripped from the start of a line.
+var bulletMarkers = []string{"- ", "* ", "+ ", "• "}
+
+// linkSchemes are the URL schemes links may use.
+var linkSchemes = []string{"https://", "http://", "mailto:"}
+
+// Parse splits a description into its non-empty lines. Indenting a line
+// deeper than the one before nests it one level under it.
+func Parse(text string) []Item {
+	var (
+		items   []Item
+		indents []int
+	)
+	for _, line := range strings.Split(text, "\n") {
+		indent := indentation(line)
+		line = trimBullet(strings.TrimSpace(line))
+		if line == "" {
+			continue
+		}
+		for len(indents) > 0 && indents[len(indents)-1] >= indent {
+			indents = indents[:len(indents)-1]
+		}
+		items = append(items, Item{Level: len(indents), Spans: ParseInline(line)})
+		indents = append(indents, indent)
+	}
+	return items
+}
+
+// Tree parses a description into its top-level lines, each holding the lines
+// nested under it.
+func Tree(text string) []Node {
+	var nodes []Node
+	for _, item := range Parse(text) {
+		siblings := &nodes
+		for level := 0; level < item.Level && len(*siblings) > 0; level++ {
+			siblings = &(*siblings)[len(*siblings)-1].Children
+		}
+		*siblings = append(*siblings, Node{Spans: item.Spans})
+	}
+	return nodes
+}
+
+// indentation returns the width of a line's leading whitespace.
+func indentation(line string) int {
+	width := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += tabWidth
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// trimBullet removes a leading bullet marker.
+func trimBullet(line string) string {
+	if line == "-" || line == "*" || line == "•" {
+		return ""
+	}
+	for _, marker := range bulletMarkers {
+		if strings.HasPrefix(line, marker) {
+			return strings.TrimSpace(line[len(marker):])
+		}
+	}
+	return line
+}
+
+// ParseInline parses the inline markup of a single line. Markers without a
+// matching closer, and links to anything but web or mail addresses, are kept
+// as plain text.
+func ParseInline(text string) []Span {
+	var (
+		spans []Span
+		plain strings.Builder
+	)
+	flush := func() {
+		if plain.Len() > 0 {
+			spans = append(spans, Span{Kind: Text, Text: plain.String()})
+			plain.Reset()
+		}
+	}
+	for i := 0; i < len(text); {
+		switch c := text[i]; {
+		case c == '\\' && i+1 < len(text) && isPunct(text[i+1]):
+			plain.WriteByte(text[i+1])
+			i += 2
+			continue
+		case c == '`':
+			if end := strings.IndexByte(text[i+1:], '`'); end > 0 {
+				flush()
+				spans = append(spans, Span{Kind: Code, Text: text[i+1 : i+1+end]})
+				i += end + 2
+				continue
+			}
+		case strings.HasPrefix(text[i:], "**"):
+			if end := closer(text, i+2, "**"); end > 0 {
+				flush()
+				spans = append(spans, Span{Kind: Strong, Children: ParseInline(text[i+2 : end])})
+				i = end + 2
+				continue
+			}
+		case c == '*' || c == '_':
+			if end := closer(text, i+1, string(c)); end > 0 && (c == '*' || wordBoundary(text, i, end)) {
+				flush()
+				spans = append(spans, Span{Kind: Emph, Children: ParseInline(text[i+1 : end])})
+				i = end + 1
+				continue
+			}
+		case c == '[':
+			if span, n, ok := parseLink(text[i:]); ok {
+				flush()
+				spans = append(spans, span)
+				i += n
+				continue
+			}
+		default:
+		}
+		plain.WriteByte(text[i])
+		i++
+	}
+	flush()
+	return spans
+}
+
+// closer finds the delimiter closing one opened just before start. The
+// content must not start or end with a space, and a single * does not close
+// on half of a **.
+func closer(text string, start int, delim string) int {
+	if start >= len(text) || text[start] == ' ' {
+		return -1
+	}
+	for i := start + 1; i < len(text); i++ {
+		switch {
+		case text[i] == '\\':
+			i++
+		case text[i] == '`':
+			if end := strings.IndexByte(text[i+1:], '`'); end >= 0 {
+				i += end + 1
+			}
+		case delim == "*" && strings.HasPrefix(text[i:], "**"):
+			if end := closer(text, i+2, "**"); end > 0 {
+				i = end + 1
+			} else {
+				i++
+			}
+		case strings.HasPrefix(text[i:], delim) && text[i-1] != ' ':
+			return i
+		default:
+		}
+	}
+	return -1
+}
+
+// wordBoundary reports whether an underscore pair opened at start and closed
+// at end stands apart from the surrounding words, so snake_case stays text.
+func wordBoundary(text string, start, end int) bool {
+	if start > 0 {
+		if r, _ := utf8.DecodeLastRuneInString(text[:start]); isWord(r) {
+			return false
+		}
+	}
+	if end+1 < len(text) {
+		if r, _ := utf8.DecodeRuneInString(text[end+1:]); isWord(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseLink parses [label](url) at the start of text and returns the span and
+// the number of bytes it used.
+func parseLink(text string) (Span, int, bool) {
+	labelEnd := strings.Index(text, "](")
+	if labelEnd < 2 || strings.Contains(text[1:labelEnd], "[") {
+		return Span{}, 0, false
+	}
+	urlEnd := strings.IndexByte(text[labelEnd+2:], ')')
+	if urlEnd < 0 {
+		return Span{}, 0, false
+	}
+	url := strings.TrimSpace(text[labelEnd+2 : labelEnd+2+urlEnd])
+	if !allowedURL(url) {
+		return Span{}, 0, false
+	}
+	span := Span{Kind: Link, URL: url, Children: ParseInline(text[1:labelEnd])}
+	return span, labelEnd + 3 + urlEnd, true
+}
+
+// allowedURL reports whether url is a web or mail address without spaces.
+func allowedURL(url string) bool {
+	if strings.ContainsFunc(url, unicode.IsSpace) {
+		return false
+	}
+	lower := strings.ToLower(url)
+	for _, scheme := range linkSchemes {
+		if strings.HasPrefix(lower, scheme) && len(url) > len(scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPunct reports whether a backslash before c escapes it, as in Markdown.
+func isPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func isWord(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,51 @@
+// Renders the rich text of CV descriptions. The Go renderers write
+// descriptions to data files as trees of lines, each a list of spans:
+// (kind: "text" | "strong" | "emph" | "code" | "link", text, url, children).
+// Text is only ever displayed, never evaluated. Plain strings, as in the
+// templates' own sample data, are shown as they are.
+
+// spans renders the inline spans of one line.
+#let spans(items) = {
+  for span in items {
+    let children = span.at("children", default: ())
+    if span.kind == "strong" {
+      strong(spans(children))
+    } else if span.kind == "emph" {
+      emph(spans(children))
+    } else if span.kind == "code" {
+      raw(span.text)
+    } else if span.kind == "link" {
+      link(span.url, spans(children))
+    } else {
+      span.text
+    }
+  }
+}
+
+// line renders a line followed by the list of lines nested under it.
+#let line(node, bullets) = {
+  if type(node) == str {
+    return node
+  }
+  spans(node.spans)
+  let children = node.at("children", default: ())
+  if children.len() > 0 {
+    bullets(children)
+  }
+}
+
+// bullets renders lines as a bullet list, nesting their children.
+#let bullets(nodes) = {
+  if nodes != none and nodes.len() > 0 {
+    list(..nodes.map(node => line(node, bullets)))
+  }
+}
+
+// paragraph renders lines as running text, for fields shown in a paragraph.
+#let paragraph(nodes) = {
+  if type(nodes) == str {
+    nodes
+  } else if nodes != none and nodes.len() > 0 {
+    nodes.map(node => line(node, bullets)).join(" ")
+  }
+}
@@ -0,0 +1,108 @@
+package richtext_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/richtext"
+)
+
+func TestParseInline(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		input string
+		want  []richtext.Span
+	}{
+		{"plain text", []richtext.Span{{Kind: richtext.Text, Text: "plain text"}}},
+		{"**bold** move", []richtext.Span{
+			{Kind: richtext.Strong, Children: []richtext.Span{{Kind: richtext.Text, Text: "bold"}}},
+			{Kind: richtext.Text, Text: " move"},
+		}},
+		{"*one* and _two_", []richtext.Span{
+			{Kind: richtext.Emph, Children: []richtext.Span{{Kind: richtext.Text, Text: "one"}}},
+			{Kind: richtext.Text, Text: " and "},
+			{Kind: richtext.Emph, Children: []richtext.Span{{Kind: richtext.Text, Text: "two"}}},
+		}},
+		{"ran `go test`", []richtext.Span{
+			{Kind: richtext.Text, Text: "ran "},
+			{Kind: richtext.Code, Text: "go test"},
+		}},
+		{"see [docs](https://go.dev)", []richtext.Span{
+			{Kind: richtext.Text, Text: "see "},
+			{Kind: richtext.Link, URL: "https://go.dev", Children: []richtext.Span{{Kind: richtext.Text, Text: "docs"}}},
+		}},
+		{"*a **b** c*", []richtext.Span{
+			{Kind: richtext.Emph, Children: []richtext.Span{
+				{Kind: richtext.Text, Text: "a "},
+				{Kind: richtext.Strong, Children: []richtext.Span{{Kind: richtext.Text, Text: "b"}}},
+				{Kind: richtext.Text, Text: " c"},
+			}},
+		}},
+		// Markers that do not pair up, snake_case and unsafe links stay text.
+		{"5 * 3 = 15", []richtext.Span{{Kind: richtext.Text, Text: "5 * 3 = 15"}}},
+		{"**open", []richtext.Span{{Kind: richtext.Text, Text: "**open"}}},
+		{"my_var_name", []richtext.Span{{Kind: richtext.Text, Text: "my_var_name"}}},
+		{"[x](javascript:alert(1))", []richtext.Span{{Kind: richtext.Text, Text: "[x](javascript:alert(1))"}}},
+		{`\*literal\*`, []richtext.Span{{Kind: richtext.Text, Text: "*literal*"}}},
+	}
+
+	for _, tc := range testCases {
+		if got := richtext.ParseInline(tc.input); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("ParseInline(%q) = %+v, want %+v", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestParseNesting(t *testing.T) {
+	t.Parallel()
+	text := "- Led the team\n  - Hired five engineers\n    * Ran interviews\n  - Set goals\n\n• Shipped it\n-5% latency\n"
+
+	items := richtext.Parse(text)
+
+	want := []struct {
+		level int
+		text  string
+	}{
+		{0, "Led the team"},
+		{1, "Hired five engineers"},
+		{2, "Ran interviews"},
+		{1, "Set goals"},
+		{0, "Shipped it"},
+		{0, "-5% latency"},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("Parse() returned %d items, want %d: %+v", len(items), len(want), items)
+	}
+	for i, w := range want {
+		if items[i].Level != w.level || items[i].Spans[0].Text != w.text {
+			t.Errorf("item %d = level %d %q, want level %d %q", i, items[i].Level, items[i].Spans[0].Text, w.level, w.text)
+		}
+	}
+}
+
+func TestTree(t *testing.T) {
+	t.Parallel()
+	nodes := richtext.Tree("Led the team\n  - Hired\n    - Interviewed\n  - Set goals\nShipped it")
+
+	if len(nodes) != 2 || len(nodes[0].Children) != 2 || len(nodes[0].Children[0].Children) != 1 || len(nodes[1].Children) != 0 {
+		t.Fatalf("Tree() = %+v, want two lines with the first holding two and one nested lines", nodes)
+	}
+	if got := nodes[0].Children[0].Children[0].Spans[0].Text; got != "Interviewed" {
+		t.Errorf("Nested line = %q, want %q", got, "Interviewed")
+	}
+}
+
+func TestNodeJSON(t *testing.T) {
+	t.Parallel()
+	got, err := json.Marshal(richtext.Tree("**Go** at [work](https://x.dev)\n  - `make`"))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `[{"spans":[{"kind":"strong","children":[{"kind":"text","text":"Go"}]},{"kind":"text","text":" at "},` +
+		`{"kind":"link","url":"https://x.dev","children":[{"kind":"text","text":"work"}]}],` +
+		`"children":[{"spans":[{"kind":"code","text":"make"}]}]}]`
+	if string(got) != want {
+		t.Errorf("JSON = %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,291 @@
+package schema
+
+import (
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+)
+
+var (
+	cvType        = reflect.TypeFor[cv.CV]()
+	dateType      = reflect.TypeFor[cv.Date]()
+	skillsType    = reflect.TypeFor[[]cv.Skill]()
+	languagesType = reflect.TypeFor[[]cv.Language]()
+	listTypes     = []reflect.Type{reflect.TypeFor[[]string](), skillsType, languagesType}
+)
+
+// EntryName is the form name of a field inside entry index of a group.
+func EntryName(group, index, name string) string {
+	return group + "[" + index + "][" + name + "]"
+}
+
+// Indices returns the entry indices of a group present in values, in order.
+// Indices may have gaps where the user removed an entry.
+func Indices(values url.Values, group string) []int {
+	var indices []int
+	prefix := group + "["
+	for key := range values {
+		rest, found := strings.CutPrefix(key, prefix)
+		if !found {
+			continue
+		}
+		number, rest, found := strings.Cut(rest, "]")
+		if !found || !strings.HasPrefix(rest, "[") {
+			continue
+		}
+		index, err := strconv.Atoi(number)
+		if err != nil || index < 0 || slices.Contains(indices, index) {
+			continue
+		}
+		indices = append(indices, index)
+	}
+	slices.Sort(indices)
+	return indices
+}
+
+// Decode reads submitted form values into a CV. Group entries whose fields
+// are all empty are skipped.
+func Decode(fields []Field, values url.Values) *cv.CV {
+	data := &cv.CV{}
+	decodeFields(reflect.ValueOf(data).Elem(), fields, values)
+	return data
+}
+
+func decodeFields(root reflect.Value, fields []Field, values url.Values) {
+	for _, field := range fields {
+		switch field.Type {
+		case Section:
+			decodeFields(root, field.Fields, values)
+		case File:
+		case Group:
+			if target, ok := lookup(root, field.Bind); ok {
+				decodeGroup(target, field, values)
+			}
+		case List:
+			if target, ok := lookup(root, field.Bind); ok {
+				decodeList(target, field, values.Get(field.Name))
+			}
+		default:
+			if target, ok := lookup(root, field.Bind); ok {
+				setValue(target, values.Get(field.Name))
+			}
+		}
+	}
+}
+
+func decodeGroup(target reflect.Value, field Field, values url.Values) {
+	for _, index := range Indices(values, field.Name) {
+		key := func(name string) string { return EntryName(field.Name, strconv.Itoa(index), name) }
+		if !entryFilled(field, values, key) {
+			continue
+		}
+
+		entry := reflect.New(target.Type().Elem()).Elem()
+		for _, sub := range field.Fields {
+			if subTarget, ok := lookup(entry, sub.Bind); ok {
+				setValue(subTarget, values.Get(key(sub.Name)))
+			}
+		}
+		if skill, ok := entry.Addr().Interface().(*cv.Skill); ok {
+			skill.Category = field.Category
+		}
+		target.Set(reflect.Append(target, entry))
+	}
+}
+
+func decodeList(target reflect.Value, field Field, value string) {
+	for _, item := range cv.SplitList(value) {
+		var element any
+		switch target.Type() {
+		case skillsType:
+			element = cv.Skill{Name: item, Category: field.Category}
+		case languagesType:
+			element = cv.ParseLanguage(item)
+		default:
+			element = item
+		}
+		target.Set(reflect.Append(target, reflect.ValueOf(element)))
+	}
+}
+
+func setValue(target reflect.Value, value string) {
+	value = strings.TrimSpace(value)
+	if target.Type() == dateType {
+		// Invalid dates are rejected by CheckValues and left empty here
+		if date, err := cv.ParseDate(value); err == nil {
+			target.Set(reflect.ValueOf(date))
+		}
+		return
+	}
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(value)
+	case reflect.Int:
+		// Invalid numbers are left at zero for renderers to default
+		if number, err := strconv.Atoi(value); err == nil {
+			target.SetInt(int64(number))
+		}
+	default:
+	}
+}
+
+// Encode is the inverse of Decode: it returns the form values that show data
+// in a form built from fields. Values the fields do not bind are dropped.
+func Encode(fields []Field, data *cv.CV) url.Values {
+	values := url.Values{}
+	encodeFields(values, reflect.ValueOf(data).Elem(), fields)
+	return values
+}
+
+func encodeFields(values url.Values, root reflect.Value, fields []Field) {
+	for _, field := range fields {
+		switch field.Type {
+		case Section:
+			encodeFields(values, root, field.Fields)
+		case File:
+		case Group:
+			if source, ok := lookup(root, field.Bind); ok {
+				encodeGroup(values, source, field)
+			}
+		case List:
+			if source, ok := lookup(root, field.Bind); ok {
+				setNonEmpty(values, field.Name, strings.Join(listItems(source, field.Category), ", "))
+			}
+		default:
+			if source, ok := lookup(root, field.Bind); ok {
+				setNonEmpty(values, field.Name, formatValue(source))
+			}
+		}
+	}
+}
+
+func encodeGroup(values url.Values, source reflect.Value, field Field) {
+	index := 0
+	for i := range source.Len() {
+		entry := source.Index(i)
+		if skill, ok := entry.Interface().(cv.Skill); ok && skill.Category != field.Category {
+			continue
+		}
+		for _, sub := range field.Fields {
+			if subSource, ok := lookup(entry, sub.Bind); ok {
+				setNonEmpty(values, EntryName(field.Name, strconv.Itoa(index), sub.Name), formatValue(subSource))
+			}
+		}
+		index++
+	}
+}
+
+// listItems returns the items of a bound list as the user would type them.
+// Skills are limited to the given category.
+func listItems(source reflect.Value, category string) []string {
+	var items []string
+	switch list := source.Interface().(type) {
+	case []cv.Skill:
+		for _, skill := range list {
+			if skill.Category == category {
+				items = append(items, skill.Name)
+			}
+		}
+	case []cv.Language:
+		for _, language := range list {
+			items = append(items, language.String())
+		}
+	case []string:
+		items = list
+	}
+	return items
+}
+
+func formatValue(source reflect.Value) string {
+	if date, ok := source.Interface().(cv.Date); ok {
+		// ISO dates, with an ongoing end date spelled as the forms suggest
+		return date.Format(cv.DateISO, "en")
+	}
+	switch source.Kind() {
+	case reflect.String:
+		return source.String()
+	case reflect.Int:
+		if source.Int() != 0 {
+			return strconv.FormatInt(source.Int(), 10)
+		}
+	default:
+	}
+	return ""
+}
+
+func setNonEmpty(values url.Values, key, value string) {
+	if value != "" {
+		values.Set(key, value)
+	}
+}
+
+// Defaults returns the values of a new form: each field's default, with the
+// defaults of a group's fields filling its first entry.
+func Defaults(fields []Field) url.Values {
+	values := url.Values{}
+	for _, field := range fields {
+		switch field.Type {
+		case Section:
+			for key, value := range Defaults(field.Fields) {
+				values[key] = value
+			}
+		case Group:
+			for _, sub := range field.Fields {
+				setNonEmpty(values, EntryName(field.Name, "0", sub.Name), sub.Default)
+			}
+		default:
+			setNonEmpty(values, field.Name, field.Default)
+		}
+	}
+	return values
+}
+
+// lookup returns the value bound by path in root.
+func lookup(root reflect.Value, path string) (reflect.Value, bool) {
+	index, ok := fieldIndex(root.Type(), path)
+	if !ok {
+		return reflect.Value{}, false
+	}
+	return root.FieldByIndex(index), true
+}
+
+// bindType returns the type of the value bound by path in t.
+func bindType(t reflect.Type, path string) (reflect.Type, bool) {
+	index, ok := fieldIndex(t, path)
+	if !ok {
+		return nil, false
+	}
+	return t.FieldByIndex(index).Type, true
+}
+
+// fieldIndex resolves a path of json names to a struct field index.
+func fieldIndex(t reflect.Type, path string) ([]int, bool) {
+	var index []int
+	for name := range strings.SplitSeq(path, ".") {
+		if t.Kind() != reflect.Struct {
+			return nil, false
+		}
+		field, found := fieldByJSONName(t, name)
+		if !found {
+			return nil, false
+		}
+		index = append(index, field.Index...)
+		t = field.Type
+	}
+	return index, true
+}
+
+func fieldByJSONName(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name != "" && tag == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
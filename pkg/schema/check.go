@@ -0,0 +1,179 @@
+package schema
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Errors maps form names to validation messages.
+type Errors map[string]string
+
+// FormError is the key of errors that belong to no single field.
+const FormError = ""
+
+// Default maximum lengths of single-line and multi-line values.
+const (
+	maxLineLength = 200
+	maxTextLength = 5000
+)
+
+var (
+	emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s.]+$`)
+	phonePattern = regexp.MustCompile(`^\+?[0-9 ()./-]+$`)
+	colorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+)
+
+// formats maps the names accepted by Field.Format to their checks and
+// messages.
+var formats = map[string]struct {
+	valid   func(string) bool
+	message string
+}{
+	"email": {emailPattern.MatchString, "Enter a valid email address"},
+	"phone": {isPhone, "Enter a valid phone number"},
+	"url":   {isURL, "Enter a valid web address"},
+	"color": {colorPattern.MatchString, "Enter a hex color such as #26428b"},
+	"date":  {validDate, "Enter a date such as 2023, Aug 2023, 2023-08-15 or Present"},
+}
+
+// format returns the format a field's value is checked against, which is
+// implied by the email, tel, url and color types.
+func (f Field) format() string {
+	if f.Format != "" {
+		return f.Format
+	}
+	switch f.Type {
+	case Email:
+		return "email"
+	case Tel:
+		return "phone"
+	case URL:
+		return "url"
+	case Color:
+		return "color"
+	default:
+		return ""
+	}
+}
+
+func (f Field) maxLength() int {
+	switch {
+	case f.MaxLength > 0:
+		return f.MaxLength
+	case f.Type == TextArea || f.Type == List:
+		return maxTextLength
+	default:
+		return maxLineLength
+	}
+}
+
+// CheckValues validates submitted form values against fields. Entries of a
+// group are only checked once any of their fields is filled in, since Decode
+// skips empty entries.
+func CheckValues(fields []Field, values url.Values) Errors {
+	errs := Errors{}
+	checkFields(errs, fields, values, func(name string) string { return name })
+	return errs
+}
+
+// checkFields checks fields whose form names are given by key.
+func checkFields(errs Errors, fields []Field, values url.Values, key func(string) string) {
+	for _, field := range fields {
+		switch field.Type {
+		case Section:
+			checkFields(errs, field.Fields, values, key)
+		case Group:
+			for _, index := range Indices(values, field.Name) {
+				entryKey := func(name string) string { return EntryName(field.Name, strconv.Itoa(index), name) }
+				if entryFilled(field, values, entryKey) {
+					checkFields(errs, field.Fields, values, entryKey)
+				}
+			}
+		case File:
+		default:
+			value := strings.TrimSpace(values.Get(key(field.Name)))
+			message := checkValue(field, value)
+			if message == "" && field.After != "" {
+				message = checkOrder(fields, field, value, strings.TrimSpace(values.Get(key(field.After))))
+			}
+			if message != "" {
+				errs[key(field.Name)] = message
+			}
+		}
+	}
+}
+
+func checkValue(field Field, value string) string {
+	if value == "" {
+		if field.Required {
+			return "This field is required"
+		}
+		return ""
+	}
+
+	if limit := field.maxLength(); utf8.RuneCountInString(value) > limit {
+		return fmt.Sprintf("Must be at most %d characters", limit)
+	}
+	if format, exists := formats[field.format()]; exists && !format.valid(value) {
+		return format.message
+	}
+	if field.Type == Select && !slices.ContainsFunc(field.Options, func(option Option) bool { return option.Value == value }) {
+		return "Choose one of the listed options"
+	}
+	return ""
+}
+
+// checkOrder reports an end date that lies before the start date in field.After.
+// Dates that cannot be parsed are not compared.
+func checkOrder(fields []Field, field Field, end, start string) string {
+	if !dateBefore(end, start) {
+		return ""
+	}
+	label := field.After
+	for _, sibling := range fields {
+		if sibling.Name == field.After && sibling.Label != "" {
+			label = sibling.Label
+		}
+	}
+	return "Must not be before " + label
+}
+
+// entryFilled reports whether any field of a group entry other than a select
+// has a value. A select always has a value, so it does not count.
+func entryFilled(group Field, values url.Values, key func(string) string) bool {
+	for _, field := range group.Fields {
+		if field.Type != Select && strings.TrimSpace(values.Get(key(field.Name))) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func isPhone(value string) bool {
+	digits := 0
+	for _, r := range value {
+		if unicode.IsDigit(r) {
+			digits++
+		}
+	}
+	return phonePattern.MatchString(value) && digits >= 5 && digits <= 20
+}
+
+// isURL accepts web addresses with or without a scheme, since renderers add
+// https:// to bare hosts.
+func isURL(value string) bool {
+	if !strings.Contains(value, "://") {
+		value = "https://" + value
+	}
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return false
+	}
+	return strings.Contains(parsed.Hostname(), ".") && !strings.ContainsAny(value, " \t")
+}
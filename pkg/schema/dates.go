@@ -0,0 +1,26 @@
+package schema
+
+import "github.com/AlexTLDR/mycv.quest/pkg/cv"
+
+// validDate reports whether value is a date cv.ParseDate understands.
+func validDate(value string) bool {
+	_, err := cv.ParseDate(value)
+	return err == nil
+}
+
+// dateBefore reports whether end is earlier than start. Dates are compared
+// at the precision both have, so "2023" is not before "Aug 2023".
+func dateBefore(end, start string) bool {
+	endDate, endErr := cv.ParseDate(end)
+	startDate, startErr := cv.ParseDate(start)
+	if endErr != nil || startErr != nil || endDate.IsZero() || startDate.IsZero() {
+		return false
+	}
+	if endDate.Month == 0 || startDate.Month == 0 {
+		endDate.Month, startDate.Month = 0, 0
+	}
+	if endDate.Day == 0 || startDate.Day == 0 {
+		endDate.Day, startDate.Day = 0, 0
+	}
+	return endDate.Compare(startDate) < 0
+}
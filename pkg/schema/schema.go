@@ -0,0 +1,204 @@
+// Package schema describes the form fields a template asks for. Schemas are
+// declared in template manifests; the same schema renders the form and decodes
+// the submitted values into a CV.
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+)
+
+// Type is the kind of input a field renders as.
+type Type string
+
+const (
+	Text     Type = "text"
+	TextArea Type = "textarea"
+	Email    Type = "email"
+	URL      Type = "url"
+	Tel      Type = "tel"
+	Color    Type = "color"
+	Select   Type = "select"
+	File     Type = "file"
+	// List is a comma-separated text area bound to a slice, such as skills.
+	List Type = "list"
+	// Group is a repeatable set of fields, such as one job.
+	Group Type = "group"
+	// Section is a titled card of fields. It has no value of its own.
+	Section Type = "section"
+)
+
+var types = []Type{Text, TextArea, Email, URL, Tel, Color, Select, File, List, Group, Section}
+
+var fieldName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Field is one input of a template form.
+type Field struct {
+	Name  string `yaml:"name"`
+	Label string `yaml:"label"`
+	Type  Type   `yaml:"type"`
+	// Bind is the CV value the field reads and writes, as a path of json
+	// names such as "person.name" or "contact.github.url". Fields inside a
+	// group bind relative to one entry of the group.
+	Bind string `yaml:"bind"`
+	// Category is the category of the skills a list or group binds.
+	Category string `yaml:"category"`
+	Required bool   `yaml:"required"`
+	// Format checks the value as "email", "phone", "url", "color" or "date".
+	// The email, tel, url and color types imply their format; fields that
+	// bind a date must declare the date format.
+	Format string `yaml:"format"`
+	// MaxLength defaults to 200 characters, or 5000 for text areas and lists.
+	MaxLength int `yaml:"max_length"`
+	// After names an earlier date field of the same section or group entry
+	// that this date must not precede.
+	After       string   `yaml:"after"`
+	Placeholder string   `yaml:"placeholder"`
+	Help        string   `yaml:"help"`
+	Options     []Option `yaml:"options"`
+	// Accept is the accept attribute of a file input.
+	Accept string `yaml:"accept"`
+	// Default prefills a new form. In groups it prefills the first entry.
+	Default string `yaml:"default"`
+	// Wide fields span both columns of the form. Text areas, lists and file
+	// inputs always do.
+	Wide bool `yaml:"wide"`
+	Rows int  `yaml:"rows"`
+	// ItemLabel titles each entry of a group.
+	ItemLabel string  `yaml:"item_label"`
+	Fields    []Field `yaml:"fields"`
+}
+
+// Option is one choice of a select field. In manifests an option may be
+// given as a plain string, which is used as both value and label.
+type Option struct {
+	Value string `yaml:"value"`
+	Label string `yaml:"label"`
+}
+
+func (o *Option) UnmarshalYAML(unmarshal func(any) error) error {
+	var value string
+	if err := unmarshal(&value); err == nil {
+		*o = Option{Value: value, Label: value}
+		return nil
+	}
+
+	type plain Option
+	if err := unmarshal((*plain)(o)); err != nil {
+		return err
+	}
+	if o.Label == "" {
+		o.Label = o.Value
+	}
+	return nil
+}
+
+// IsWide reports whether the field spans both columns of the form.
+func (f Field) IsWide() bool {
+	return f.Wide || f.Type == TextArea || f.Type == List || f.Type == File
+}
+
+// Validate checks field names, types and nesting, and that every binding
+// refers to a CV value of a suitable type.
+func Validate(fields []Field) error {
+	return validateFields(fields, cvType, "", make(map[string]bool))
+}
+
+// validateFields checks fields bound to target. parent is the type of the
+// enclosing field, or empty at the top level. Sections share the names of
+// their parent; groups have their own.
+func validateFields(fields []Field, target reflect.Type, parent Type, seen map[string]bool) error {
+	for _, field := range fields {
+		if !fieldName.MatchString(field.Name) {
+			return fmt.Errorf("invalid field name %q", field.Name)
+		}
+		if seen[field.Name] {
+			return fmt.Errorf("duplicate field %q", field.Name)
+		}
+		seen[field.Name] = true
+
+		if !slices.Contains(types, field.Type) {
+			return fmt.Errorf("field %q has unknown type %q", field.Name, field.Type)
+		}
+		if err := validateField(field, target, parent, seen); err != nil {
+			return err
+		}
+	}
+
+	for i, field := range fields {
+		if field.After != "" && !slices.ContainsFunc(fields[:i], func(f Field) bool { return f.Name == field.After }) {
+			return fmt.Errorf("field %q must come after field %q", field.Name, field.After)
+		}
+	}
+	return nil
+}
+
+func validateField(field Field, target reflect.Type, parent Type, seen map[string]bool) error {
+	if field.Type == Select && len(field.Options) == 0 {
+		return fmt.Errorf("select field %q has no options", field.Name)
+	}
+	if _, exists := formats[field.Format]; field.Format != "" && !exists {
+		return fmt.Errorf("field %q has unknown format %q", field.Name, field.Format)
+	}
+	if len(field.Fields) > 0 && field.Type != Group && field.Type != Section {
+		return fmt.Errorf("field %q of type %q cannot have sub-fields", field.Name, field.Type)
+	}
+
+	switch field.Type {
+	case Section, Group:
+		if parent != "" {
+			return fmt.Errorf("%s %q cannot be nested in a %s", field.Type, field.Name, parent)
+		}
+		if len(field.Fields) == 0 {
+			return fmt.Errorf("%s %q has no fields", field.Type, field.Name)
+		}
+	case File:
+		if field.Bind != "" || parent == Group {
+			return fmt.Errorf("file field %q cannot be bound or repeated", field.Name)
+		}
+		return nil
+	default:
+	}
+
+	if field.Type == Section {
+		if field.Bind != "" {
+			return fmt.Errorf("section %q cannot be bound", field.Name)
+		}
+		if err := validateFields(field.Fields, target, Section, seen); err != nil {
+			return fmt.Errorf("section %q: %w", field.Name, err)
+		}
+		return nil
+	}
+
+	bound, ok := bindType(target, field.Bind)
+	if !ok {
+		return fmt.Errorf("field %q binds unknown value %q", field.Name, field.Bind)
+	}
+	if field.Category != "" && bound != skillsType {
+		return fmt.Errorf("field %q has a category but does not bind skills", field.Name)
+	}
+
+	switch field.Type {
+	case Group:
+		if bound.Kind() != reflect.Slice || bound.Elem().Kind() != reflect.Struct {
+			return fmt.Errorf("group %q must bind a list of entries", field.Name)
+		}
+		if err := validateFields(field.Fields, bound.Elem(), Group, make(map[string]bool)); err != nil {
+			return fmt.Errorf("group %q: %w", field.Name, err)
+		}
+	case List:
+		if !slices.Contains(listTypes, bound) {
+			return fmt.Errorf("list %q must bind a list of strings, skills or languages", field.Name)
+		}
+	default:
+		if bound == dateType && field.format() != "date" {
+			return fmt.Errorf("field %q binds a date and must have the date format", field.Name)
+		}
+		if bound != dateType && bound.Kind() != reflect.String && bound.Kind() != reflect.Int {
+			return fmt.Errorf("field %q must bind a text or number value", field.Name)
+		}
+	}
+	return nil
+}
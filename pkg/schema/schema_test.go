@@ -0,0 +1,256 @@
+package schema_test
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+	"github.com/AlexTLDR/mycv.quest/pkg/schema"
+	"gopkg.in/yaml.v2"
+)
+
+var testFields = []schema.Field{
+	{Name: "personal", Label: "Personal", Type: schema.Section, Fields: []schema.Field{
+		{Name: "name", Type: schema.Text, Bind: "person.name", Default: "John Doe"},
+		{Name: "github", Type: schema.Text, Bind: "contact.github.url"},
+		{Name: "photo", Type: schema.File},
+	}},
+	{Name: "jobs", Type: schema.Group, Bind: "experience", Fields: []schema.Field{
+		{Name: "position", Type: schema.Text, Bind: "title", Default: "Engineer"},
+		{Name: "company", Type: schema.Text, Bind: "company"},
+	}},
+	{Name: "expertise", Type: schema.Group, Bind: "skills", Category: cv.CategoryExpertise, Fields: []schema.Field{
+		{Name: "name", Type: schema.Text, Bind: "name"},
+		{Name: "level", Type: schema.Select, Bind: "level", Options: []schema.Option{{Value: "1"}, {Value: "5"}}},
+	}},
+	{Name: "tools", Type: schema.List, Bind: "skills", Category: cv.CategoryTools},
+	{Name: "languages", Type: schema.List, Bind: "languages"},
+	{Name: "interests", Type: schema.List, Bind: "interests"},
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	if err := schema.Validate(testFields); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	testCases := []struct {
+		name    string
+		fields  []schema.Field
+		wantErr string
+	}{
+		{"unknown bind", []schema.Field{{Name: "a", Type: schema.Text, Bind: "person.age"}}, "binds unknown value"},
+		{"missing bind", []schema.Field{{Name: "a", Type: schema.Text}}, "binds unknown value"},
+		{"scalar bound to list", []schema.Field{{Name: "a", Type: schema.Text, Bind: "skills"}}, "text or number"},
+		{"list bound to scalar", []schema.Field{{Name: "a", Type: schema.List, Bind: "person.name"}}, "must bind a list"},
+		{"group bound to strings", []schema.Field{{Name: "a", Type: schema.Group, Bind: "interests", Fields: []schema.Field{{Name: "b", Type: schema.Text}}}}, "list of entries"},
+		{"category without skills", []schema.Field{{Name: "a", Type: schema.List, Bind: "interests", Category: "Tools"}}, "does not bind skills"},
+		{"bound file", []schema.Field{{Name: "a", Type: schema.File, Bind: "person.name"}}, "cannot be bound"},
+		{"nested section", []schema.Field{{Name: "a", Type: schema.Section, Fields: []schema.Field{{Name: "b", Type: schema.Section}}}}, "cannot be nested"},
+		{"duplicate in section", []schema.Field{
+			{Name: "a", Type: schema.Text, Bind: "person.name"},
+			{Name: "s", Type: schema.Section, Fields: []schema.Field{{Name: "a", Type: schema.Text, Bind: "person.title"}}},
+		}, "duplicate field"},
+		{"bad group entry bind", []schema.Field{{Name: "a", Type: schema.Group, Bind: "experience", Fields: []schema.Field{{Name: "b", Type: schema.Text, Bind: "person.name"}}}}, "binds unknown value"},
+		{"date without format", []schema.Field{{Name: "a", Type: schema.Group, Bind: "experience", Fields: []schema.Field{{Name: "b", Type: schema.Text, Bind: "start_date"}}}}, "must have the date format"},
+	}
+
+	for _, tc := range testCases {
+		err := schema.Validate(tc.fields)
+		if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("%s: expected error containing %q, got: %v", tc.name, tc.wantErr, err)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+	values := url.Values{
+		"name":                 {"  Jane Doe "},
+		"github":               {"janedoe"},
+		"jobs[0][position]":    {"Engineer"},
+		"jobs[0][company]":     {"Acme"},
+		"jobs[1][position]":    {""},
+		"jobs[3][position]":    {"Lead"},
+		"expertise[0][name]":   {"Go"},
+		"expertise[0][level]":  {"5"},
+		"expertise[1][name]":   {""},
+		"expertise[1][level]":  {"1"},
+		"expertise[2][name]":   {"Rust"},
+		"expertise[2][level]":  {"invalid"},
+		"tools":                {"Git, Docker"},
+		"languages":            {"German (native), English"},
+		"interests":            {"Hiking,, Chess"},
+		"unrelated[0][ignore]": {"x"},
+	}
+
+	data := schema.Decode(testFields, values)
+
+	if data.Person.Name != "Jane Doe" || data.Contact.GitHub.URL != "janedoe" {
+		t.Errorf("Unexpected person or contact: %+v %+v", data.Person, data.Contact)
+	}
+	// Entries left empty and gaps from removed entries are skipped
+	if len(data.Experience) != 2 || data.Experience[0].Company != "Acme" || data.Experience[1].Title != "Lead" {
+		t.Errorf("Unexpected experience: %+v", data.Experience)
+	}
+
+	wantSkills := []cv.Skill{
+		{Name: "Go", Category: cv.CategoryExpertise, Level: 5},
+		{Name: "Rust", Category: cv.CategoryExpertise},
+		{Name: "Git", Category: cv.CategoryTools},
+		{Name: "Docker", Category: cv.CategoryTools},
+	}
+	if !reflect.DeepEqual(data.Skills, wantSkills) {
+		t.Errorf("Skills = %+v, want %+v", data.Skills, wantSkills)
+	}
+
+	wantLanguages := []cv.Language{{Name: "German", Fluency: "native"}, {Name: "English"}}
+	if !reflect.DeepEqual(data.Languages, wantLanguages) {
+		t.Errorf("Languages = %+v, want %+v", data.Languages, wantLanguages)
+	}
+	if !reflect.DeepEqual(data.Interests, []string{"Hiking", "Chess"}) {
+		t.Errorf("Unexpected interests: %v", data.Interests)
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	t.Parallel()
+	data := &cv.CV{
+		Person:     cv.Person{Name: "Jane Doe"},
+		Contact:    cv.Contact{GitHub: cv.Link{URL: "janedoe"}},
+		Experience: []cv.Experience{{Title: "Engineer", Company: "Acme"}, {Title: "Lead"}},
+		Skills: []cv.Skill{
+			{Name: "Git", Category: cv.CategoryTools},
+			{Name: "Go", Category: cv.CategoryExpertise, Level: 5},
+		},
+		Languages: []cv.Language{{Name: "German", Fluency: "native"}},
+		Interests: []string{"Hiking", "Chess"},
+	}
+
+	values := schema.Encode(testFields, data)
+	if values.Get("tools") != "Git" || values.Get("expertise[0][name]") != "Go" || values.Get("jobs[1][position]") != "Lead" {
+		t.Errorf("Unexpected encoded values: %v", values)
+	}
+
+	decoded := schema.Decode(testFields, values)
+	// Decode appends fields in schema order, so the expertise group comes first
+	data.Skills[0], data.Skills[1] = data.Skills[1], data.Skills[0]
+	if !reflect.DeepEqual(decoded, data) {
+		t.Errorf("Round trip changed the CV:\n got %+v\nwant %+v", decoded, data)
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	t.Parallel()
+	values := schema.Defaults(testFields)
+	want := url.Values{"name": {"John Doe"}, "jobs[0][position]": {"Engineer"}}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("Defaults = %v, want %v", values, want)
+	}
+}
+
+func TestIndices(t *testing.T) {
+	t.Parallel()
+	values := url.Values{
+		"jobs[2][position]": {"b"},
+		"jobs[0][position]": {"a"},
+		"jobs[0][company]":  {"a"},
+		"jobs[x][position]": {"bad"},
+		"jobs[5]":           {"no field"},
+		"jobsextra[1][a]":   {"other"},
+	}
+	if got := schema.Indices(values, "jobs"); !reflect.DeepEqual(got, []int{0, 2}) {
+		t.Errorf("Indices = %v, want [0 2]", got)
+	}
+}
+
+func TestOptionYAML(t *testing.T) {
+	t.Parallel()
+	var options []schema.Option
+	if err := yaml.Unmarshal([]byte("- Remote\n- {value: \"4\", label: Advanced}\n- {value: x}"), &options); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	want := []schema.Option{{Value: "Remote", Label: "Remote"}, {Value: "4", Label: "Advanced"}, {Value: "x", Label: "x"}}
+	if !reflect.DeepEqual(options, want) {
+		t.Errorf("Options = %+v, want %+v", options, want)
+	}
+}
+
+func TestCheckValues(t *testing.T) {
+	t.Parallel()
+	fields := []schema.Field{
+		{Name: "name", Label: "Name", Type: schema.Text, Bind: "person.name", Required: true, MaxLength: 10},
+		{Name: "email", Type: schema.Email, Bind: "contact.email"},
+		{Name: "phone", Type: schema.Tel, Bind: "contact.phone"},
+		{Name: "site", Type: schema.Text, Format: "url", Bind: "contact.website.url"},
+		{Name: "accent_color", Type: schema.Color, Bind: "theme.accent_color"},
+		{Name: "jobs", Type: schema.Group, Bind: "experience", Fields: []schema.Field{
+			{Name: "title", Type: schema.Text, Bind: "title", Required: true},
+			{Name: "from", Label: "Start Date", Type: schema.Text, Format: "date", Bind: "start_date"},
+			{Name: "to", Type: schema.Text, Format: "date", Bind: "end_date", After: "from"},
+		}},
+		{Name: "expertise", Type: schema.Group, Bind: "skills", Fields: []schema.Field{
+			{Name: "name", Type: schema.Text, Bind: "name"},
+			{Name: "level", Type: schema.Select, Bind: "level", Options: []schema.Option{{Value: "1"}, {Value: "2"}}},
+		}},
+	}
+	if err := schema.Validate(fields); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	valid := url.Values{
+		"name":                {"Jane"},
+		"email":               {"jane@example.com"},
+		"phone":               {"+1 (555) 123-4567"},
+		"site":                {"jane.dev/about"},
+		"accent_color":        {"#26428b"},
+		"jobs[0][title]":      {"Engineer"},
+		"jobs[0][from]":       {"Aug 2021"},
+		"jobs[0][to]":         {"Present"},
+		"jobs[1][title]":      {"Intern"},
+		"jobs[1][from]":       {"2019"},
+		"jobs[1][to]":         {"Mar 2019"},
+		"jobs[2][title]":      {""},
+		"expertise[0][name]":  {"Go"},
+		"expertise[0][level]": {"2"},
+		"expertise[1][name]":  {""},
+		"expertise[1][level]": {"1"},
+	}
+	if errs := schema.CheckValues(fields, valid); len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+
+	invalid := url.Values{
+		"name":                {"Jane Marie Doe"},
+		"email":               {"jane@"},
+		"phone":               {"call me"},
+		"site":                {"not a site"},
+		"accent_color":        {"blue"},
+		"jobs[0][from]":       {"08/2021"},
+		"jobs[0][to]":         {"2020 Mar."},
+		"jobs[1][title]":      {"Intern"},
+		"jobs[1][from]":       {"last summer"},
+		"expertise[0][name]":  {"Go"},
+		"expertise[0][level]": {"9"},
+	}
+	want := schema.Errors{
+		"name":                "Must be at most 10 characters",
+		"email":               "Enter a valid email address",
+		"phone":               "Enter a valid phone number",
+		"site":                "Enter a valid web address",
+		"accent_color":        "Enter a hex color such as #26428b",
+		"jobs[0][title]":      "This field is required",
+		"jobs[0][to]":         "Must not be before Start Date",
+		"jobs[1][from]":       "Enter a date such as 2023, Aug 2023, 2023-08-15 or Present",
+		"expertise[0][level]": "Choose one of the listed options",
+	}
+	if errs := schema.CheckValues(fields, invalid); !reflect.DeepEqual(errs, want) {
+		t.Errorf("CheckValues = %v, want %v", errs, want)
+	}
+
+	if errs := schema.CheckValues(fields, url.Values{}); errs["name"] != "This field is required" || len(errs) != 1 {
+		t.Errorf("Expected only the required name error, got %v", errs)
+	}
+}
@@ -0,0 +1,213 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/drafts"
+	"github.com/AlexTLDR/mycv.quest/templates"
+)
+
+const (
+	ownerCookie = "drafts_owner"
+	// Drafts outlive sessions, so the owner cookie is kept for a year
+	ownerCookieMaxAge = 365 * 24 * 3600
+)
+
+// draftOwner returns the owner ID from the request's cookie, or an empty
+// string when the browser has not saved a draft yet.
+func draftOwner(r *http.Request) string {
+	cookie, err := r.Cookie(ownerCookie)
+	if err != nil || !drafts.ValidID(cookie.Value) {
+		return ""
+	}
+	return cookie.Value
+}
+
+// ensureDraftOwner returns the request's owner ID, issuing a new one if needed.
+func ensureDraftOwner(w http.ResponseWriter, r *http.Request) string {
+	owner := draftOwner(r)
+	if owner == "" {
+		owner = drafts.NewID()
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     ownerCookie,
+		Value:    owner,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   false, // Set to true in production with HTTPS
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   ownerCookieMaxAge,
+	})
+	return owner
+}
+
+// HandleDraftList shows the drafts of the requesting browser.
+func (s *Server) HandleDraftList(w http.ResponseWriter, r *http.Request) {
+	var list []drafts.Draft
+	if owner := draftOwner(r); owner != "" {
+		var err error
+		if list, err = s.drafts.List(r.Context(), owner); err != nil {
+			log.Printf("Listing drafts failed: %v", err)
+			http.Error(w, "Failed to list drafts", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	names := make(map[string]string)
+	for _, template := range s.generator.GetTemplateData() {
+		names[template.Key] = template.Name
+	}
+
+	items := make([]templates.DraftItem, 0, len(list))
+	for _, draft := range list {
+		name, exists := names[draft.TemplateKey]
+		if !exists {
+			name = draft.TemplateKey
+		}
+		items = append(items, templates.DraftItem{
+			ID:           draft.ID,
+			Name:         draft.Name,
+			TemplateName: name,
+			Updated:      draft.UpdatedAt.Local().Format("Jan 2, 2006 15:04"),
+			Public:       draft.Public,
+		})
+	}
+
+	if err := templates.DraftList(items).Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+	}
+}
+
+// HandleDraftSave saves the submitted form as a draft: a new one, or the one
+// named by the form's draft ID.
+func (s *Server) HandleDraftSave(w http.ResponseWriter, r *http.Request) {
+	templateKey := strings.TrimPrefix(r.URL.Path, "/drafts/save/")
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	values, err := s.generator.FormValues(templateKey, r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error saving draft: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	name := strings.TrimSpace(values.Get(drafts.NameField))
+	if name == "" {
+		name = "Untitled draft"
+	}
+
+	owner := ensureDraftOwner(w, r)
+	id := values.Get(drafts.IDField)
+
+	// Saving changes to a public draft keeps its profile online. An ID the
+	// owner does not have, such as one from another browser, saves a new draft.
+	public := false
+	if id != "" {
+		existing, err := s.drafts.Get(r.Context(), owner, id)
+		switch {
+		case errors.Is(err, drafts.ErrNotFound):
+			id = ""
+		case err != nil:
+			s.draftError(w, r, err)
+			return
+		default:
+			public = existing.Public
+		}
+	}
+
+	draft, err := s.drafts.Save(r.Context(), drafts.Draft{
+		ID:          id,
+		Owner:       owner,
+		Name:        name,
+		TemplateKey: templateKey,
+		Values:      values,
+		Public:      public,
+	})
+	if err != nil {
+		s.draftError(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, "/drafts/"+draft.ID+"?saved=1", http.StatusSeeOther)
+}
+
+// HandleDraft serves a single draft:
+//
+//	GET  /drafts/{id}            the template form filled with the draft
+//	POST /drafts/{id}/duplicate  a copy of the draft
+//	POST /drafts/{id}/delete     removes the draft
+//	POST /drafts/{id}/publish    shows the draft at /profile/{id}
+//	POST /drafts/{id}/unpublish  takes the profile down again
+func (s *Server) HandleDraft(w http.ResponseWriter, r *http.Request) {
+	id, action, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/drafts/"), "/")
+	owner := draftOwner(r)
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		s.showDraft(w, r, owner, id)
+	case action == "duplicate" && r.Method == http.MethodPost:
+		draft, err := drafts.Duplicate(r.Context(), s.drafts, owner, id)
+		if err != nil {
+			s.draftError(w, r, err)
+			return
+		}
+		http.Redirect(w, r, "/drafts/"+draft.ID+"?saved=1", http.StatusSeeOther)
+	case action == "delete" && r.Method == http.MethodPost:
+		if err := s.drafts.Delete(r.Context(), owner, id); err != nil {
+			s.draftError(w, r, err)
+			return
+		}
+		http.Redirect(w, r, "/drafts", http.StatusSeeOther)
+	case (action == "publish" || action == "unpublish") && r.Method == http.MethodPost:
+		if _, err := drafts.Publish(r.Context(), s.drafts, owner, id, action == "publish"); err != nil {
+			s.draftError(w, r, err)
+			return
+		}
+		http.Redirect(w, r, "/drafts", http.StatusSeeOther)
+	case action == "" || action == "duplicate" || action == "delete" || action == "publish" || action == "unpublish":
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) showDraft(w http.ResponseWriter, r *http.Request, owner, id string) {
+	draft, err := s.drafts.Get(r.Context(), owner, id)
+	if err != nil {
+		s.draftError(w, r, err)
+		return
+	}
+
+	values := draft.Values
+	values.Set(drafts.IDField, draft.ID)
+	values.Set(drafts.NameField, draft.Name)
+
+	view, exists := s.generator.GetFormView(draft.TemplateKey, values, nil)
+	if !exists {
+		http.Error(w, fmt.Sprintf("Template '%s' of this draft no longer exists", draft.TemplateKey), http.StatusNotFound)
+		return
+	}
+	if r.URL.Query().Has("saved") {
+		view.Notice = fmt.Sprintf("Draft %q saved.", draft.Name)
+	}
+
+	if err := templates.SchemaForm(view).Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) draftError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, drafts.ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	log.Printf("Draft store failed: %v", err)
+	http.Error(w, "Failed to access drafts", http.StatusInternalServerError)
+}
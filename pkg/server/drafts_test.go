@@ -0,0 +1,150 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestDrafts(t *testing.T) {
+	t.Parallel()
+	server := setupTestServer()
+
+	// Drafts may be incomplete, so saving does not validate
+	formData := url.Values{
+		"name":       {"Draft User"},
+		"email":      {"draft@"},
+		"draft_name": {"Backend roles"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/drafts/save/basic", strings.NewReader(formData.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	server.HandleDraftSave(w, req)
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusSeeOther {
+		t.Fatalf("Expected status 303, got %d", resp.StatusCode)
+	}
+	cookies := resp.Cookies()
+	if len(cookies) != 1 || cookies[0].Name != "drafts_owner" {
+		t.Fatalf("Expected a drafts_owner cookie, got %v", cookies)
+	}
+	location := resp.Header.Get("Location")
+	draftPath, _, _ := strings.Cut(location, "?")
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.AddCookie(cookies[0])
+		w := httptest.NewRecorder()
+		if path == "/drafts" {
+			server.HandleDraftList(w, req)
+		} else {
+			server.HandleDraft(w, req)
+		}
+		return w
+	}
+	post := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.AddCookie(cookies[0])
+		w := httptest.NewRecorder()
+		server.HandleDraft(w, req)
+		return w
+	}
+
+	w = get(location)
+	body := w.Body.String()
+	for _, expected := range []string{
+		`Draft &#34;Backend roles&#34; saved.`,
+		`action="/generate/basic"`,
+		`value="Draft User"`,
+		`value="draft@"`,
+		`name="draft_id" value="` + strings.TrimPrefix(draftPath, "/drafts/") + `"`,
+		`name="draft_name" value="Backend roles"`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Draft form missing %s", expected)
+		}
+	}
+
+	w = post(draftPath + "/duplicate")
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("Expected status 303 for duplicate, got %d", w.Code)
+	}
+	body = get("/drafts").Body.String()
+	if !strings.Contains(body, "Backend roles") || !strings.Contains(body, "Copy of Backend roles") || !strings.Contains(body, "Basic Resume") {
+		t.Errorf("Draft list missing drafts: %s", body)
+	}
+
+	if w := post(draftPath + "/delete"); w.Code != http.StatusSeeOther {
+		t.Errorf("Expected status 303 for delete, got %d", w.Code)
+	}
+	if w := get(draftPath); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for a deleted draft, got %d", w.Code)
+	}
+	if w := get(draftPath + "/delete"); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, got %d", w.Code)
+	}
+}
+
+func TestDraftsAreScopedToOwner(t *testing.T) {
+	t.Parallel()
+	server := setupTestServer()
+
+	req := httptest.NewRequest(http.MethodPost, "/drafts/save/vantage", strings.NewReader("name=Private"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	server.HandleDraftSave(w, req)
+	draftPath, _, _ := strings.Cut(w.Header().Get("Location"), "?")
+
+	// Without the owner cookie the draft cannot be seen or removed
+	req = httptest.NewRequest(http.MethodGet, draftPath, nil)
+	w = httptest.NewRecorder()
+	server.HandleDraft(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for another browser, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, draftPath+"/delete", nil)
+	req.AddCookie(&http.Cookie{Name: "drafts_owner", Value: strings.Repeat("ab", 16)})
+	w = httptest.NewRecorder()
+	server.HandleDraft(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 deleting another owner's draft, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/drafts", nil)
+	w = httptest.NewRecorder()
+	server.HandleDraftList(w, req)
+	if !strings.Contains(w.Body.String(), "You have no saved drafts") {
+		t.Error("Expected an empty draft list without a cookie")
+	}
+
+	// Saving with another owner's draft ID creates a new draft instead
+	formData := url.Values{"name": {"Taken over"}, "draft_id": {strings.TrimPrefix(draftPath, "/drafts/")}}
+	req = httptest.NewRequest(http.MethodPost, "/drafts/save/vantage", strings.NewReader(formData.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = httptest.NewRecorder()
+	server.HandleDraftSave(w, req)
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("Expected status 303, got %d", w.Code)
+	}
+	if otherPath, _, _ := strings.Cut(w.Header().Get("Location"), "?"); otherPath == draftPath {
+		t.Error("Expected a new draft rather than the other owner's")
+	}
+}
+
+func TestHandleDraftSaveInvalidTemplate(t *testing.T) {
+	t.Parallel()
+	server := setupTestServer()
+
+	req := httptest.NewRequest(http.MethodPost, "/drafts/save/nonexistent", strings.NewReader("name=x"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	server.HandleDraftSave(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
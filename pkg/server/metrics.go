@@ -0,0 +1,49 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// HandleMetrics reports the compile queue and the render cache in the
+// Prometheus text format.
+func (s *Server) HandleMetrics(w http.ResponseWriter, _ *http.Request) {
+	stats := s.generator.Scheduler().Stats()
+	cache := s.generator.Cache().Stats()
+
+	var b strings.Builder
+	metric := func(name, kind, help string, value float64) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n%s %s\n", name, help, name, kind, name, strconv.FormatFloat(value, 'g', -1, 64))
+	}
+	metric("mycv_compile_workers", "gauge", "Typst compilations that may run at once.", float64(stats.Workers))
+	metric("mycv_compile_queue_capacity", "gauge", "Compilations that may wait for a worker.", float64(stats.QueueSize))
+	metric("mycv_compile_running", "gauge", "Typst compilations running.", float64(stats.Running))
+	metric("mycv_compile_queue_depth", "gauge", "Compilations waiting for a worker.", float64(stats.Queued))
+	metric("mycv_compile_completed_total", "counter", "Compilations finished, successfully or not.", float64(stats.Completed))
+	metric("mycv_compile_rejected_total", "counter", "Compilations rejected because the queue was full.", float64(stats.Rejected))
+	metric("mycv_compile_timeouts_total", "counter", "Compilations stopped by the timeout.", float64(stats.TimedOut))
+	metric("mycv_compile_wait_seconds_total", "counter", "Time compilations waited for a worker.", stats.WaitTotal.Seconds())
+	metric("mycv_compile_wait_seconds_max", "gauge", "Longest time a compilation waited for a worker.", stats.WaitMax.Seconds())
+	metric("mycv_compile_run_seconds_total", "counter", "Time compilations spent running.", stats.RunTotal.Seconds())
+	metric("mycv_render_cache_entries", "gauge", "PDFs in the render cache.", float64(cache.Entries))
+	metric("mycv_render_cache_bytes", "gauge", "Size of the PDFs in the render cache.", float64(cache.Bytes))
+	metric("mycv_render_cache_capacity_bytes", "gauge", "Size the render cache may grow to.", float64(cache.MaxBytes))
+	metric("mycv_render_cache_hits_total", "counter", "Generations served from the render cache.", float64(cache.Hits))
+	metric("mycv_render_cache_misses_total", "counter", "Generations not found in the render cache.", float64(cache.Misses))
+	metric("mycv_render_cache_evictions_total", "counter", "PDFs evicted to keep the render cache within its size.", float64(cache.Evictions))
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if _, err := w.Write([]byte(b.String())); err != nil {
+		http.Error(w, "Failed to write metrics", http.StatusInternalServerError)
+	}
+}
+
+// setRetryAfter tells a client turned away by a full compile queue when to
+// try again, in whole seconds.
+func (s *Server) setRetryAfter(w http.ResponseWriter) {
+	seconds := math.Ceil(s.generator.Scheduler().RetryAfter().Seconds())
+	w.Header().Set("Retry-After", strconv.Itoa(int(seconds)))
+}
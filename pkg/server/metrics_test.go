@@ -0,0 +1,72 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/drafts"
+	"github.com/AlexTLDR/mycv.quest/pkg/generator"
+	"github.com/AlexTLDR/mycv.quest/pkg/server"
+)
+
+func TestBusyServer(t *testing.T) {
+	t.Parallel()
+	scheduler, err := generator.NewScheduler(generator.SchedulerConfig{Workers: 1})
+	if err != nil {
+		t.Fatalf("NewScheduler failed: %v", err)
+	}
+	server := server.New(generator.NewWithOptions(testConfig(), generator.Options{Compiler: &generator.FakeCompiler{}, Scheduler: scheduler}), drafts.NewMemoryStore())
+
+	// Occupy the only worker; with no queue every compilation is turned away
+	release, started := make(chan struct{}), make(chan struct{})
+	go func() {
+		_ = scheduler.Run(context.Background(), func(context.Context) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+	defer close(release)
+
+	formData := url.Values{"name": {"Jane Doe"}, "email": {"jane@example.com"}}
+	for _, path := range []string{"/generate/basic", "/preview/basic"} {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(formData.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		if path == "/generate/basic" {
+			server.HandleGenerate(w, req)
+		} else {
+			server.HandlePreview(w, req)
+		}
+
+		if w.Code != http.StatusServiceUnavailable {
+			t.Errorf("%s: expected status 503, got %d", path, w.Code)
+		}
+		if w.Header().Get("Retry-After") != "1" {
+			t.Errorf("%s: expected Retry-After 1, got %q", path, w.Header().Get("Retry-After"))
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	w := httptest.NewRecorder()
+	server.HandleMetrics(w, req)
+
+	body := w.Body.String()
+	for _, expected := range []string{
+		"# TYPE mycv_compile_running gauge",
+		"mycv_compile_workers 1\n",
+		"mycv_compile_running 1\n",
+		"mycv_compile_queue_depth 0\n",
+		"mycv_compile_rejected_total 2\n",
+		"mycv_render_cache_misses_total 2\n",
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Metrics missing %q:\n%s", expected, body)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/generator"
+	"github.com/AlexTLDR/mycv.quest/templates"
+)
+
+// previewSuffix is appended to the template key to store a session's preview
+// apart from the CV it generated.
+const previewSuffix = "-preview"
+
+// previews tracks the preview render in progress for each session and
+// template, so that a newer request cancels the stale one.
+type previews struct {
+	mutex   sync.Mutex
+	running map[string]*previewRun
+}
+
+type previewRun struct {
+	cancel context.CancelFunc
+}
+
+func newPreviews() *previews {
+	return &previews{running: make(map[string]*previewRun)}
+}
+
+// start cancels the render running under key and returns the context of the
+// new one. done must be called once the new render has finished.
+func (p *previews) start(ctx context.Context, key string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	run := &previewRun{cancel: cancel}
+
+	p.mutex.Lock()
+	if stale, exists := p.running[key]; exists {
+		stale.cancel()
+	}
+	p.running[key] = run
+	p.mutex.Unlock()
+
+	return ctx, func() {
+		cancel()
+		p.mutex.Lock()
+		if p.running[key] == run {
+			delete(p.running, key)
+		}
+		p.mutex.Unlock()
+	}
+}
+
+// HandlePreview renders the posted form state and returns an HTML fragment
+// showing the PDF, for the form's live preview. The render is canceled when
+// the client goes away or a newer preview of the same form arrives.
+func (s *Server) HandlePreview(w http.ResponseWriter, r *http.Request) {
+	templateKey := strings.TrimPrefix(r.URL.Path, "/preview/")
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.generator.HasTemplate(templateKey) {
+		http.NotFound(w, r)
+		return
+	}
+
+	session := s.sessionManager.GetOrCreateSession(r)
+	s.sessionManager.SetSessionCookie(w, session)
+
+	ctx, done := s.previews.start(r.Context(), session.ID+"/"+templateKey)
+	defer done()
+
+	pdfData, err := s.generator.PreviewFromForm(ctx, templateKey, r)
+	if errors.Is(err, context.Canceled) {
+		// A newer preview replaces this one, so there is nothing to show
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if errors.Is(err, generator.ErrQueueFull) {
+		// The form keeps showing the last preview until the next change
+		s.setRetryAfter(w)
+		http.Error(w, "Too many CVs are being generated", http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		// Sent with status 200, since htmx only swaps successful responses
+		log.Printf("Preview of template %s failed: %v", templateKey, err)
+		if err := templates.PreviewError("The preview could not be rendered. Check the form for mistakes.").Render(r.Context(), w); err != nil {
+			log.Printf("Failed to render preview: %v", err)
+		}
+		return
+	}
+
+	s.sessionManager.StorePDF(session.ID, templateKey+previewSuffix, pdfData)
+
+	// The version changes the frame's URL so the browser reloads it
+	src := fmt.Sprintf("/cv/%s/%s%s.pdf?v=%d", session.ID, templateKey, previewSuffix, time.Now().UnixNano())
+	if err := templates.PreviewFrame(src).Render(r.Context(), w); err != nil {
+		log.Printf("Failed to render preview: %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandlePreviewCanceled(t *testing.T) {
+	t.Parallel()
+	server := setupTestServer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req := httptest.NewRequest(http.MethodPost, "/preview/basic", strings.NewReader("name=Jane"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = req.WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	server.HandlePreview(w, req)
+
+	// A stale preview leaves the current one in place
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected status 204, got %d", w.Code)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("Expected an empty body, got %q", w.Body.String())
+	}
+}
+
+func TestHandlePreviewInvalidRequests(t *testing.T) {
+	t.Parallel()
+	server := setupTestServer()
+
+	req := httptest.NewRequest(http.MethodGet, "/preview/basic", nil)
+	w := httptest.NewRecorder()
+	server.HandlePreview(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/preview/nonexistent", nil)
+	w = httptest.NewRecorder()
+	server.HandlePreview(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
+
+func TestFormHasPreview(t *testing.T) {
+	t.Parallel()
+	server := setupTestServer()
+
+	req := httptest.NewRequest(http.MethodGet, "/form/basic", nil)
+	w := httptest.NewRecorder()
+	server.HandleForm(w, req)
+
+	body := w.Body.String()
+	for _, expected := range []string{`id="cv-form"`, `hx-post="/preview/basic"`, `hx-sync="this:replace"`} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Form missing %s", expected)
+		}
+	}
+}
+
+func TestHandlePreview(t *testing.T) {
+	t.Parallel()
+	server := setupTestServer()
+
+	req := httptest.NewRequest(http.MethodPost, "/preview/basic", strings.NewReader("name=Jane&email=not-yet-valid"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	server.HandlePreview(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	body := w.Body.String()
+	start := strings.Index(body, `src="`)
+	if start < 0 || !strings.Contains(body, "<iframe") {
+		t.Fatalf("Expected a preview frame, got %s", body)
+	}
+	src := body[start+len(`src="`):]
+	src = src[:strings.Index(src, `"`)]
+
+	// The frame loads the preview PDF from the session
+	req = httptest.NewRequest(http.MethodGet, strings.ReplaceAll(src, "&amp;", "&"), nil)
+	w = httptest.NewRecorder()
+	server.HandleSessionPDF(w, req)
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Body.String(), "%PDF") {
+		t.Errorf("Expected the preview PDF at %s, got status %d", src, w.Code)
+	}
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/export"
+	"github.com/AlexTLDR/mycv.quest/templates"
+)
+
+// HandleProfile serves a published draft as a web page at /profile/{id}. The
+// URL stays the same while the owner keeps editing the draft, so it can be
+// linked to from anywhere.
+func (s *Server) HandleProfile(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/profile/")
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	draft, err := s.drafts.GetPublic(r.Context(), id)
+	if err != nil {
+		s.draftError(w, r, err)
+		return
+	}
+
+	data, err := s.generator.DecodeValues(draft.TemplateKey, draft.Values)
+	if err != nil {
+		log.Printf("Profile %s cannot be shown: %v", id, err)
+		http.NotFound(w, r)
+		return
+	}
+
+	view := templates.ProfileView{
+		Document: export.Build(data),
+		Person:   export.NewPerson(data, s.profileURL(draft.ID)),
+		Style:    templates.ProfileStyle(draft.TemplateKey),
+		Lang:     data.Layout.Lang,
+	}
+	if view.Lang == "" {
+		view.Lang = "en"
+	}
+
+	if err := templates.Profile(view).Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+	}
+}
+
+// profileURL returns the absolute URL of a profile, as given in its canonical
+// link and JSON-LD, or an empty string when the public URL is not set.
+func (s *Server) profileURL(id string) string {
+	if s.publicURL == nil {
+		return ""
+	}
+	return s.publicURL.JoinPath("profile", id).String()
+}
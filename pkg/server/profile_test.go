@@ -0,0 +1,117 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestHandleProfile(t *testing.T) {
+	t.Parallel()
+	server := setupTestServer()
+	if err := server.SetPublicURL("https://cv.example/"); err != nil {
+		t.Fatalf("SetPublicURL failed: %v", err)
+	}
+
+	formData := url.Values{
+		"name":                         {"Jane </script> Smith"},
+		"title":                        {"Staff Engineer"},
+		"email":                        {"jane@example.com"},
+		"linkedin_url":                 {"https://www.linkedin.com/in/janesmith"},
+		"jobs[0][position]":            {"Lead Developer"},
+		"jobs[0][company_name]":        {"Quantum Innovations"},
+		"jobs[0][from]":                {"2023-03"},
+		"jobs[0][to]":                  {"present"},
+		"jobs[0][description]":         {"Shipped **fast** builds"},
+		"technical_expertise[0][name]": {"Kotlin"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/drafts/save/vantage", strings.NewReader(formData.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	server.HandleDraftSave(w, req)
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("Expected status 303, got %d", w.Code)
+	}
+	cookie := w.Result().Cookies()[0]
+	draftPath, _, _ := strings.Cut(w.Header().Get("Location"), "?")
+	profilePath := "/profile/" + strings.TrimPrefix(draftPath, "/drafts/")
+
+	profile := func() *httptest.ResponseRecorder {
+		// The Host header is the client's choice and must not end up in links
+		req := httptest.NewRequest(http.MethodGet, "http://attacker.example"+profilePath, nil)
+		req.Header.Set("X-Forwarded-Proto", "https")
+		w := httptest.NewRecorder()
+		server.HandleProfile(w, req)
+		return w
+	}
+	post := func(path string) {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		server.HandleDraft(w, req)
+		if w.Code != http.StatusSeeOther {
+			t.Fatalf("Expected status 303 for %s, got %d", path, w.Code)
+		}
+	}
+
+	if w := profile(); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 before publishing, got %d", w.Code)
+	}
+
+	post(draftPath + "/publish")
+	w = profile()
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	body := w.Body.String()
+	for _, expected := range []string{
+		`<body class="profile-vantage">`,
+		`<link rel="canonical" href="https://cv.example` + profilePath + `">`,
+		`<script id="person" type="application/ld+json">`,
+		`"@type":"Person","name":"Jane \u003c/script\u003e Smith","url":"https://cv.example` + profilePath + `"`,
+		`"worksFor":[{"@type":"Organization","name":"Quantum Innovations"}]`,
+		`<h1>Jane &lt;/script&gt; Smith</h1>`,
+		`<span class="title">Lead Developer</span>`,
+		`<li>Shipped <strong>fast</strong> builds</li>`,
+		`<aside><section><h2>Skills</h2>`,
+		`@media print`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Profile missing %s", expected)
+		}
+	}
+	if strings.Contains(body, "attacker.example") {
+		t.Error("Profile should not link to the requested host")
+	}
+
+	// Saving the draft again keeps it published
+	formData.Set("draft_id", strings.TrimPrefix(draftPath, "/drafts/"))
+	formData.Set("title", "Principal Engineer")
+	req = httptest.NewRequest(http.MethodPost, "/drafts/save/vantage", strings.NewReader(formData.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.AddCookie(cookie)
+	server.HandleDraftSave(httptest.NewRecorder(), req)
+	if body := profile().Body.String(); !strings.Contains(body, "Principal Engineer") {
+		t.Error("Expected the profile to show the saved changes")
+	}
+
+	post(draftPath + "/unpublish")
+	if w := profile(); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 after unpublishing, got %d", w.Code)
+	}
+}
+
+func TestSetPublicURL(t *testing.T) {
+	t.Parallel()
+	server := setupTestServer()
+	for _, raw := range []string{"cv.example", "ftp://cv.example", "https://", "://"} {
+		if err := server.SetPublicURL(raw); err == nil {
+			t.Errorf("SetPublicURL(%q) should fail", raw)
+		}
+	}
+	if err := server.SetPublicURL("https://example.com/cv"); err != nil {
+		t.Errorf("Expected a URL with a path to be accepted, got: %v", err)
+	}
+}
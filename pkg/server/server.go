@@ -1,26 +1,55 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
+	"github.com/AlexTLDR/mycv.quest/pkg/cv"
+	"github.com/AlexTLDR/mycv.quest/pkg/drafts"
+	"github.com/AlexTLDR/mycv.quest/pkg/export"
 	"github.com/AlexTLDR/mycv.quest/pkg/generator"
+	"github.com/AlexTLDR/mycv.quest/pkg/schema"
 	"github.com/AlexTLDR/mycv.quest/templates"
 )
 
 type Server struct {
 	generator      *generator.CVGenerator
 	sessionManager *SessionManager
+	drafts         drafts.Store
+	previews       *previews
+	// publicURL is the address the server is reached at, or nil if unknown.
+	publicURL *url.URL
 }
 
-func New(gen *generator.CVGenerator) *Server {
+func New(gen *generator.CVGenerator, store drafts.Store) *Server {
 	return &Server{
 		generator:      gen,
 		sessionManager: NewSessionManager(),
+		drafts:         store,
+		previews:       newPreviews(),
 	}
 }
 
+// SetPublicURL sets the address the server is reached at, such as
+// https://cv.example.com, which public profiles give as their own URL. The
+// request's Host header is chosen by the client, so without it profiles name
+// no URL at all.
+func (s *Server) SetPublicURL(raw string) error {
+	base, err := url.Parse(raw)
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return fmt.Errorf("invalid public URL %q: want an absolute http or https URL", raw)
+	}
+	base.RawQuery, base.Fragment = "", ""
+	s.publicURL = base
+	return nil
+}
+
 func (s *Server) SetupRoutes() {
 	// Serve static files
 	http.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.Dir("."))))
@@ -37,7 +66,24 @@ func (s *Server) SetupRoutes() {
 	// Generate CV endpoint
 	http.HandleFunc("/generate/", s.HandleGenerate)
 
-	// Serve session-specific generated PDFs
+	// Live preview of the form being edited
+	http.HandleFunc("/preview/", s.HandlePreview)
+
+	// Export form data as JSON Resume
+	http.HandleFunc("/export/", s.HandleExport)
+
+	// Saved drafts
+	http.HandleFunc("/drafts", s.HandleDraftList)
+	http.HandleFunc("/drafts/save/", s.HandleDraftSave)
+	http.HandleFunc("/drafts/", s.HandleDraft)
+
+	// Public profiles of published drafts
+	http.HandleFunc("/profile/", s.HandleProfile)
+
+	// Compile queue metrics for monitoring
+	http.HandleFunc("/metrics", s.HandleMetrics)
+
+	// Serve session-specific generated PDFs and page images
 	http.HandleFunc("/cv/", s.HandleSessionPDF)
 }
 
@@ -56,21 +102,14 @@ func (s *Server) HandleIndex(w http.ResponseWriter, r *http.Request) {
 func (s *Server) HandleForm(w http.ResponseWriter, r *http.Request) {
 	templateKey := strings.TrimPrefix(r.URL.Path, "/form/")
 
-	switch templateKey {
-	case "basic":
-		if err := templates.BasicForm().Render(r.Context(), w); err != nil {
-			http.Error(w, "Failed to render template", http.StatusInternalServerError)
-		}
-	case "modern":
-		if err := templates.ModernForm().Render(r.Context(), w); err != nil {
-			http.Error(w, "Failed to render template", http.StatusInternalServerError)
-		}
-	case "vantage":
-		if err := templates.VantageForm().Render(r.Context(), w); err != nil {
-			http.Error(w, "Failed to render template", http.StatusInternalServerError)
-		}
-	default:
+	form, exists := s.generator.GetForm(templateKey)
+	if !exists {
 		http.NotFound(w, r)
+		return
+	}
+
+	if err := form.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
 	}
 }
 
@@ -89,14 +128,20 @@ func (s *Server) HandleGenerate(w http.ResponseWriter, r *http.Request) {
 		s.sessionManager.SetSessionCookie(w, session)
 
 		// Generate CV in memory
-		pdfData, err := s.generator.GenerateFromForm(templateKey, r)
+		submission, err := s.generator.ReadForm(templateKey, r)
+		if err != nil {
+			s.renderGenerateError(w, r, templateKey, err)
+			return
+		}
+		pdfData, err := s.generator.GenerateFromSubmission(r.Context(), templateKey, submission, generator.Output{})
 		if err != nil {
-			http.Error(w, fmt.Sprintf("Error generating CV: %v", err), http.StatusInternalServerError)
+			s.renderGenerateError(w, r, templateKey, err)
 			return
 		}
 
-		// Store PDF in session
+		// Store PDF in session, and the CV for its page images
 		s.sessionManager.StorePDF(session.ID, templateKey, pdfData)
+		s.sessionManager.StoreSubmission(session.ID, templateKey, submission)
 
 		// Redirect to the session-specific generated PDF
 		http.Redirect(w, r, fmt.Sprintf("/cv/%s/%s.pdf", session.ID, templateKey), http.StatusSeeOther)
@@ -106,9 +151,93 @@ func (s *Server) HandleGenerate(w http.ResponseWriter, r *http.Request) {
 	http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 }
 
+// renderGenerateError shows the form again with the submitted values and the
+// reason generation failed.
+func (s *Server) renderGenerateError(w http.ResponseWriter, r *http.Request, templateKey string, err error) {
+	var validationErr *generator.ValidationError
+	var compileErr *generator.CompileError
+
+	values, errs, status := r.Form, schema.Errors{}, http.StatusInternalServerError
+	switch {
+	case errors.As(err, &validationErr):
+		values, errs, status = validationErr.Values, validationErr.Errors, http.StatusUnprocessableEntity
+	case errors.Is(err, generator.ErrQueueFull):
+		s.setRetryAfter(w)
+		status = http.StatusServiceUnavailable
+		errs[schema.FormError] = "The server is busy generating other CVs. Please try again in a few seconds."
+	case errors.Is(err, context.DeadlineExceeded):
+		log.Printf("Generating template %s timed out: %v", templateKey, err)
+		errs[schema.FormError] = "Generating the CV took too long. Please try again."
+	case errors.As(err, &compileErr):
+		// Typst's exit status and diagnostics are for the log, not the user
+		log.Printf("Typst failed for template %s: %v\n%s", templateKey, compileErr.Err, compileErr.Output)
+		errs[schema.FormError] = "The CV could not be generated from these entries. Please check them and try again."
+	default:
+		log.Printf("Generating template %s failed: %v", templateKey, err)
+		errs[schema.FormError] = "Something went wrong while generating the CV. Please try again."
+	}
+
+	form, exists := s.generator.GetFilledForm(templateKey, values, errs)
+	if !exists {
+		message := errs[schema.FormError]
+		if message == "" {
+			message = "Please correct the form and try again."
+		}
+		http.Error(w, message, status)
+		return
+	}
+
+	w.WriteHeader(status)
+	if err := form.Render(r.Context(), w); err != nil {
+		log.Printf("Failed to render form: %v", err)
+	}
+}
+
+func (s *Server) HandleExport(w http.ResponseWriter, r *http.Request) {
+	templateKey := strings.TrimPrefix(r.URL.Path, "/export/")
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	data, err := s.generator.DecodeForm(templateKey, r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error exporting CV: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	// Plain text and Markdown are exported in place of the JSON Resume
+	if name := r.URL.Query().Get("format"); name != "" {
+		format, err := export.ParseFormat(name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeExport(w, templateKey, format, data, "attachment")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"cv-%s.json\"", templateKey))
+
+	if err := cv.WriteJSONResume(w, data); err != nil {
+		http.Error(w, "Failed to write JSON Resume", http.StatusInternalServerError)
+	}
+}
+
+// HandleSessionPDF serves a session's generated CV: the PDF at
+// /cv/{sessionID}/{template}.pdf, plain text, Markdown and Word exports of the
+// same CV at {template}.txt, {template}.md and {template}.docx, and its page
+// images at /cv/{sessionID}/{template}/page-{n}.png or .svg, or zipped at
+// /cv/{sessionID}/{template}/pages-png.zip or pages-svg.zip. PNG pages take
+// their resolution from the dpi query parameter.
 func (s *Server) HandleSessionPDF(w http.ResponseWriter, r *http.Request) {
-	// Parse URL path: /cv/{sessionID}/{template}.pdf
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/cv/"), "/")
+	if len(pathParts) == 3 {
+		s.handleSessionImage(w, r, pathParts[0], pathParts[1], pathParts[2])
+		return
+	}
 	if len(pathParts) != 2 {
 		http.NotFound(w, r)
 		return
@@ -119,6 +248,22 @@ func (s *Server) HandleSessionPDF(w http.ResponseWriter, r *http.Request) {
 
 	// Extract template key from filename
 	templateKey := strings.TrimSuffix(templateFile, ".pdf")
+	for _, format := range export.Formats {
+		if key, ok := strings.CutSuffix(templateFile, "."+format.Extension()); ok {
+			submission, exists := s.sessionManager.GetSubmission(sessionID, key)
+			if !exists {
+				http.NotFound(w, r)
+				return
+			}
+			// Browsers cannot show Word files, so they are downloaded
+			disposition := "inline"
+			if format == export.DOCX {
+				disposition = "attachment"
+			}
+			writeExport(w, key, format, submission.CV, disposition)
+			return
+		}
+	}
 
 	// Get PDF data from session
 	pdfData, exists := s.sessionManager.GetPDF(sessionID, templateKey)
@@ -137,3 +282,108 @@ func (s *Server) HandleSessionPDF(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Failed to write PDF data", http.StatusInternalServerError)
 	}
 }
+
+// writeExport writes a CV as a plain text, Markdown or DOCX file.
+func writeExport(w http.ResponseWriter, templateKey string, format export.Format, data *cv.CV, disposition string) {
+	content, err := export.Render(format, data)
+	if err != nil {
+		log.Printf("Exporting template %s as %s failed: %v", templateKey, format, err)
+		http.Error(w, "Failed to export the CV", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("%s; filename=\"cv-%s.%s\"", disposition, templateKey, format.Extension()))
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	if _, err := w.Write(content); err != nil {
+		log.Printf("Failed to write the %s export: %v", format, err)
+	}
+}
+
+// handleSessionImage compiles the page images of a session's CV, named by
+// filename.
+func (s *Server) handleSessionImage(w http.ResponseWriter, r *http.Request, sessionID, templateKey, filename string) {
+	output, ok := parseImageFilename(filename)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if dpi := r.URL.Query().Get("dpi"); dpi != "" && output.Format == generator.FormatPNG {
+		value, err := strconv.Atoi(dpi)
+		if err != nil || value < 1 {
+			http.Error(w, "Invalid dpi", http.StatusBadRequest)
+			return
+		}
+		output.DPI = value
+	}
+	if err := output.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	submission, exists := s.sessionManager.GetSubmission(sessionID, templateKey)
+	if !exists {
+		http.NotFound(w, r)
+		return
+	}
+
+	data, err := s.generator.GenerateFromSubmission(r.Context(), templateKey, submission, output)
+	switch {
+	case errors.Is(err, generator.ErrPageNotFound):
+		http.NotFound(w, r)
+		return
+	case errors.Is(err, generator.ErrQueueFull):
+		s.setRetryAfter(w)
+		http.Error(w, "Too many CVs are being generated", http.StatusServiceUnavailable)
+		return
+	case err != nil:
+		log.Printf("Rendering %s of template %s failed: %v", filename, templateKey, err)
+		http.Error(w, "Failed to render the CV", http.StatusInternalServerError)
+		return
+	default:
+	}
+
+	w.Header().Set("Content-Type", output.ContentType())
+	disposition := "inline"
+	if output.Page == 0 {
+		disposition = "attachment"
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("%s; filename=\"cv-%s-%s\"", disposition, templateKey, filename))
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	if _, err := w.Write(data); err != nil {
+		log.Printf("Failed to write %s: %v", filename, err)
+	}
+}
+
+// parseImageFilename returns the output a session image URL names:
+// page-{n}.png, page-{n}.svg, pages-png.zip or pages-svg.zip.
+func parseImageFilename(filename string) (generator.Output, bool) {
+	if format, ok := strings.CutPrefix(filename, "pages-"); ok {
+		format, ok = strings.CutSuffix(format, ".zip")
+		if !ok {
+			return generator.Output{}, false
+		}
+		return imageOutput(format, 0)
+	}
+
+	page, ok := strings.CutPrefix(filename, "page-")
+	if !ok {
+		return generator.Output{}, false
+	}
+	page, format, ok := strings.Cut(page, ".")
+	if !ok {
+		return generator.Output{}, false
+	}
+	number, err := strconv.Atoi(page)
+	if err != nil || number < 1 {
+		return generator.Output{}, false
+	}
+	return imageOutput(format, number)
+}
+
+func imageOutput(format string, page int) (generator.Output, bool) {
+	parsed, err := generator.ParseFormat(format)
+	if err != nil || parsed == generator.FormatPDF {
+		return generator.Output{}, false
+	}
+	return generator.Output{Format: parsed, Page: page}, true
+}
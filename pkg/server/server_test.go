@@ -2,44 +2,59 @@ package server_test
 
 import (
 	"bytes"
+	"errors"
+	"image"
+	"image/png"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/AlexTLDR/mycv.quest/pkg/config"
+	"github.com/AlexTLDR/mycv.quest/pkg/drafts"
 	"github.com/AlexTLDR/mycv.quest/pkg/generator"
+	"github.com/AlexTLDR/mycv.quest/pkg/renderer"
+	"github.com/AlexTLDR/mycv.quest/pkg/schema"
 	"github.com/AlexTLDR/mycv.quest/pkg/server"
 )
 
 func setupTestServer() *server.Server {
-	cfg := &config.Config{
+	gen := generator.NewWithOptions(testConfig(), generator.Options{Compiler: &generator.FakeCompiler{}})
+	return server.New(gen, drafts.NewMemoryStore())
+}
+
+func testConfig() *config.Config {
+	return &config.Config{
 		Templates: map[string]config.Template{
 			"basic": {
 				Name:      "Basic Resume",
 				Dir:       "../../templates/basic/template",
-				InputFile: "main.typ",
+				InputFile: "cv.typ",
+				Renderer:  renderer.Basic{},
+				Fields:    bundledFields("basic"),
 			},
 			"modern": {
 				Name:       "Modern Resume",
 				Dir:        "../../templates/modern/template",
-				InputFile:  "main.typ",
+				InputFile:  "cv.typ",
 				NeedsPhoto: true,
+				Renderer:   renderer.Modern{},
+				Fields:     bundledFields("modern"),
 			},
 			"vantage": {
 				Name:      "Vantage Resume",
 				Dir:       "../../templates/vantage",
 				InputFile: "example.typ",
+				Renderer:  renderer.Vantage{},
+				Fields:    bundledFields("vantage"),
 			},
 		},
 		OutputDir: "test_output",
 	}
-
-	gen := generator.New(cfg)
-	return server.New(gen)
 }
 
 func TestHandleIndex(t *testing.T) {
@@ -132,6 +147,30 @@ func TestHandleForm(t *testing.T) {
 	}
 }
 
+func TestHandleFormSchema(t *testing.T) {
+	t.Parallel()
+	server := setupTestServer()
+
+	req := httptest.NewRequest(http.MethodGet, "/form/vantage", nil)
+	w := httptest.NewRecorder()
+
+	server.HandleForm(w, req)
+
+	body := w.Body.String()
+	for _, expected := range []string{
+		`action="/generate/vantage"`,
+		`name="linkedin_display_text"`,
+		`name="jobs[0][company_name]" value="Quantum Innovations"`,
+		`<option value="4" selected>`,
+		`name="technical_expertise[__INDEX__][name]"`,
+		`name="tools"`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Form missing %s", expected)
+		}
+	}
+}
+
 func TestHandleGenerateGET(t *testing.T) {
 	t.Parallel()
 	server := setupTestServer()
@@ -217,6 +256,111 @@ func TestHandleGeneratePOSTBasic(t *testing.T) {
 	}
 }
 
+func TestHandleGenerateValidationError(t *testing.T) {
+	t.Parallel()
+	server := setupTestServer()
+
+	formData := url.Values{
+		"name":                      {"Test User"},
+		"email":                     {"test@"},
+		"education[0][institution]": {"Test University"},
+		"education[0][start_date]":  {"May 2020"},
+		"education[0][end_date]":    {"Jan 2020"},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/generate/basic", strings.NewReader(formData.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	server.HandleGenerate(w, req)
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status 422, got %d", resp.StatusCode)
+	}
+
+	body := w.Body.String()
+	for _, expected := range []string{
+		"Please correct the highlighted fields.",
+		"Enter a valid email address",
+		"Must not be before Start Date",
+		`value="test@"`,
+		`value="Test University"`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Form missing %s", expected)
+		}
+	}
+	// Entries the user removed are not brought back with defaults
+	if strings.Contains(body, `name="work[0][title]"`) {
+		t.Error("Form should only show the submitted entries")
+	}
+}
+
+func TestHandleGenerateInvalidResumeUpload(t *testing.T) {
+	t.Parallel()
+	server := setupTestServer()
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	if err := writer.WriteField("name", "Jane Doe"); err != nil {
+		t.Fatalf("Failed to write form field: %v", err)
+	}
+	part, err := writer.CreateFormFile(generator.ResumeUploadField, "resume.json")
+	if err != nil {
+		t.Fatalf("Failed to create form file: %v", err)
+	}
+	if _, err := part.Write([]byte("{not json")); err != nil {
+		t.Fatalf("Failed to write resume: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to close writer: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/generate/basic", &buf)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	server.HandleGenerate(w, req)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status 422, got %d", w.Code)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, "This file is not a valid JSON Resume document") || !strings.Contains(body, `value="Jane Doe"`) {
+		t.Error("Expected the form again with the upload error and the entries")
+	}
+	if strings.Contains(body, "invalid character") {
+		t.Error("Error page should not show the decoder's error")
+	}
+}
+
+func TestHandleGenerateCompileError(t *testing.T) {
+	t.Parallel()
+	compiler := &generator.FakeCompiler{Err: &generator.CompileError{Err: errors.New("exit status 1"), Output: "error: unknown variable: secret"}}
+	server := server.New(generator.NewWithOptions(testConfig(), generator.Options{Compiler: compiler}), drafts.NewMemoryStore())
+
+	formData := url.Values{"name": {"Jane Doe"}, "email": {"jane@example.com"}}
+	req := httptest.NewRequest(http.MethodPost, "/generate/basic", strings.NewReader(formData.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	server.HandleGenerate(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", w.Code)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, "The CV could not be generated from these entries.") {
+		t.Error("Expected a friendly error message")
+	}
+	for _, internal := range []string{"exit status", "typst", "secret"} {
+		if strings.Contains(body, internal) {
+			t.Errorf("Error page should not show %q", internal)
+		}
+	}
+}
+
 func TestHandleGeneratePOSTModernWithPhoto(t *testing.T) {
 	t.Parallel()
 	server := setupTestServer()
@@ -249,13 +393,9 @@ func TestHandleGeneratePOSTModernWithPhoto(t *testing.T) {
 		t.Fatalf("Failed to create form file: %v", err)
 	}
 
-	// Write a minimal PNG header (enough for detection)
-	pngHeader := []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A}
-	if _, err := fileWriter.Write(pngHeader); err != nil {
-		t.Fatalf("Failed to write PNG header: %v", err)
-	}
-	if _, err := fileWriter.Write([]byte("fake png data")); err != nil {
-		t.Fatalf("Failed to write PNG data: %v", err)
+	// Write a small PNG, which the photo pipeline decodes
+	if err := png.Encode(fileWriter, image.NewGray(image.Rect(0, 0, 64, 48))); err != nil {
+		t.Fatalf("Failed to write PNG: %v", err)
 	}
 
 	if err := writer.Close(); err != nil {
@@ -270,12 +410,12 @@ func TestHandleGeneratePOSTModernWithPhoto(t *testing.T) {
 
 	resp := w.Result()
 
-	// The test might fail with typst compilation error due to invalid PNG
-	// but we still want to test the multipart form handling
+	// The test might fail with typst compilation error in the test
+	// environment, but we still want to test the multipart form handling
 	if resp.StatusCode == http.StatusInternalServerError {
 		body, _ := io.ReadAll(resp.Body)
-		if strings.Contains(string(body), "typst compilation failed") {
-			t.Skip("Typst compilation failed due to invalid PNG - this is expected in test environment")
+		if strings.Contains(string(body), "could not be generated") {
+			t.Skip("Typst compilation failed - this is expected in test environment")
 		}
 		t.Errorf("Unexpected server error: %s", string(body))
 	}
@@ -375,6 +515,49 @@ func TestHandleSessionPDFNotFound(t *testing.T) {
 	}
 }
 
+func TestHandleSessionImages(t *testing.T) {
+	t.Parallel()
+	server := setupTestServer()
+
+	formData := url.Values{
+		"name":  {"Test User"},
+		"email": {"test@example.com"},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/generate/basic", strings.NewReader(formData.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	server.HandleGenerate(w, req)
+	base := strings.TrimSuffix(w.Header().Get("Location"), ".pdf")
+
+	testCases := []struct {
+		path        string
+		status      int
+		contentType string
+	}{
+		{base + "/page-1.png?dpi=300", http.StatusOK, "image/png"},
+		{base + "/page-1.svg", http.StatusOK, "image/svg+xml"},
+		{base + "/pages-png.zip", http.StatusOK, "application/zip"},
+		{base + "/page-2.png", http.StatusNotFound, ""},
+		{base + "/page-1.png?dpi=9000", http.StatusBadRequest, ""},
+		{base + "/page-1.pdf", http.StatusNotFound, ""},
+		{base + "/pages.zip", http.StatusNotFound, ""},
+		{"/cv/nonexistent/basic/page-1.png", http.StatusNotFound, ""},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		server.HandleSessionPDF(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+		resp := w.Result()
+		if resp.StatusCode != tc.status {
+			t.Errorf("%s: expected status %d, got %d", tc.path, tc.status, resp.StatusCode)
+		}
+		if tc.contentType != "" && resp.Header.Get("Content-Type") != tc.contentType {
+			t.Errorf("%s: expected Content-Type %s, got %s", tc.path, tc.contentType, resp.Header.Get("Content-Type"))
+		}
+	}
+}
+
 func TestSessionManagement(t *testing.T) {
 	t.Parallel()
 	server := setupTestServer()
@@ -409,6 +592,7 @@ func TestSessionManagement(t *testing.T) {
 	// Generate vantage CV with same session
 	formData2 := url.Values{
 		"name":     {"Test User 2"},
+		"title":    {"Software Engineer"},
 		"email":    {"test2@example.com"},
 		"position": {"Developer"},
 	}
@@ -498,3 +682,108 @@ func TestConcurrentSessions(t *testing.T) {
 		t.Error("User 2 should be able to access their PDF")
 	}
 }
+
+func TestHandleExport(t *testing.T) {
+	t.Parallel()
+	server := setupTestServer()
+
+	formData := url.Values{
+		"name":                      {"Test User"},
+		"email":                     {"test@example.com"},
+		"education[0][institution]": {"Test University"},
+		"work[0][title]":            {"Test Job"},
+		"work[0][company]":          {"Test Company"},
+		"programming_languages":     {"Go, Rust"},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/export/basic", strings.NewReader(formData.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	server.HandleExport(w, req)
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("Expected Content-Type application/json, got %s", contentType)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	for _, expected := range []string{`"name": "Test User"`, `"institution": "Test University"`, `"position": "Test Job"`, `"Go"`} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("Exported JSON missing %s", expected)
+		}
+	}
+}
+
+func TestHandleExportText(t *testing.T) {
+	t.Parallel()
+	server := setupTestServer()
+
+	formData := url.Values{
+		"name":                 {"Test User"},
+		"email":                {"test@example.com"},
+		"work[0][title]":       {"Test Job"},
+		"work[0][company]":     {"Test Company"},
+		"work[0][description]": {"Shipped **fast**"},
+	}
+
+	for format, expected := range map[string]string{
+		"text":     "TEST USER\n",
+		"markdown": "- Shipped **fast**\n",
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/export/basic?format="+format, strings.NewReader(formData.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		server.HandleExport(w, req)
+
+		body := w.Body.String()
+		if w.Code != http.StatusOK || !strings.Contains(body, expected) {
+			t.Errorf("%s export: status %d, missing %q:\n%s", format, w.Code, expected, body)
+		}
+	}
+
+	// The same exports sit next to a generated PDF
+	req := httptest.NewRequest(http.MethodPost, "/generate/basic", strings.NewReader(formData.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	server.HandleGenerate(w, req)
+	base := strings.TrimSuffix(w.Header().Get("Location"), ".pdf")
+
+	w = httptest.NewRecorder()
+	server.HandleSessionPDF(w, httptest.NewRequest(http.MethodGet, base+".md", nil))
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/markdown; charset=utf-8" || !strings.Contains(w.Body.String(), "# Test User") {
+		t.Errorf("Expected the Markdown export of the session's CV, got %d:\n%s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	server.HandleSessionPDF(w, httptest.NewRequest(http.MethodGet, base+".docx", nil))
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Disposition"), "attachment;") || !strings.HasPrefix(w.Body.String(), "PK") {
+		t.Errorf("Expected the session's CV as a Word download, got %d %v", w.Code, w.Header())
+	}
+}
+
+func TestHandleExportInvalidTemplate(t *testing.T) {
+	t.Parallel()
+	server := setupTestServer()
+
+	req := httptest.NewRequest(http.MethodPost, "/export/nonexistent", nil)
+	w := httptest.NewRecorder()
+
+	server.HandleExport(w, req)
+
+	if w.Result().StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Result().StatusCode)
+	}
+}
+
+// bundledFields returns the field schema from a bundled template's manifest.
+func bundledFields(key string) []schema.Field {
+	manifest, err := config.ReadManifest(filepath.Join("../../templates", key, config.ManifestFile))
+	if err != nil {
+		panic(err)
+	}
+	return manifest.Fields
+}
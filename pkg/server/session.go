@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"sync"
 	"time"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/generator"
 )
 
 type SessionManager struct {
@@ -18,6 +20,9 @@ type Session struct {
 	ID        string
 	CreatedAt time.Time
 	PDFData   map[string][]byte // templateKey -> PDF content
+	// Submissions keeps each generated CV by template key, to compile its
+	// page images on request.
+	Submissions map[string]*generator.Submission
 }
 
 func NewSessionManager() *SessionManager {
@@ -47,9 +52,10 @@ func (sm *SessionManager) CreateSession() *Session {
 	sessionID := generateSessionID()
 
 	session := &Session{
-		ID:        sessionID,
-		CreatedAt: time.Now(),
-		PDFData:   make(map[string][]byte),
+		ID:          sessionID,
+		CreatedAt:   time.Now(),
+		PDFData:     make(map[string][]byte),
+		Submissions: make(map[string]*generator.Submission),
 	}
 
 	sm.mutex.Lock()
@@ -104,6 +110,29 @@ func (sm *SessionManager) GetPDF(sessionID, templateKey string) ([]byte, bool) {
 	return nil, false
 }
 
+// StoreSubmission keeps the submission a session's CV was generated from.
+func (sm *SessionManager) StoreSubmission(sessionID, templateKey string, submission *generator.Submission) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	if session, exists := sm.sessions[sessionID]; exists {
+		session.Submissions[templateKey] = submission
+	}
+}
+
+// GetSubmission returns the submission a session's CV was generated from.
+func (sm *SessionManager) GetSubmission(sessionID, templateKey string) (*generator.Submission, bool) {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	if session, exists := sm.sessions[sessionID]; exists {
+		if submission, exists := session.Submissions[templateKey]; exists {
+			return submission, true
+		}
+	}
+	return nil, false
+}
+
 func (sm *SessionManager) cleanupExpiredSessions() {
 	ticker := time.NewTicker(5 * time.Minute)
 	defer ticker.Stop()
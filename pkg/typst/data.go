@@ -0,0 +1,18 @@
+package typst
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Data cleans s for a data file, such as YAML, whose values a template
+// displays itself. Typst applies no syntax to those, so only what the file
+// format or a PDF cannot carry is dropped.
+func Data(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r != '\n' && r != '\t' && unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, strings.ToValidUTF8(s, "\uFFFD"))
+}
@@ -0,0 +1,99 @@
+package typst_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/typst"
+	"gopkg.in/yaml.v2"
+)
+
+// seeds are inputs that would break a template if they became Typst code.
+var seeds = []string{
+	"",
+	"plain text",
+	`John "The Great" Doe`,
+	`back\slash\`,
+	"#set page(width: 1cm) $x^2$ `raw` *bold* _emph_",
+	"] #panic() [",
+	"= Heading\n- item\n+ enum\n/ term: x\n12. numbered",
+	"  - indented\n\t= tabbed",
+	"<label> @ref ~ // comment /* block */",
+	"https://example.com/a_b",
+	"line\r\nbreak\x00\x1b[31m",
+	"separated\u2028= heading\u2029- item",
+	"invalid \xff utf-8",
+	"Zürich — 東京 🚀",
+}
+
+func FuzzData(f *testing.F) {
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		value := typst.Data(s)
+		if !utf8.ValidString(value) {
+			t.Fatalf("Data(%q) = %q is not valid UTF-8", s, value)
+		}
+		if strings.ContainsFunc(value, func(r rune) bool { return r != '\n' && r != '\t' && unicode.IsControl(r) }) {
+			t.Fatalf("Data(%q) = %q keeps control characters", s, value)
+		}
+
+		file, err := yaml.Marshal(map[string]string{"value": value})
+		if err != nil {
+			t.Fatalf("Marshal(%q) failed: %v", value, err)
+		}
+		var decoded map[string]string
+		if err := yaml.Unmarshal(file, &decoded); err != nil || decoded["value"] != value {
+			t.Fatalf("Data(%q) = %q does not survive YAML: %q, %v", s, value, decoded["value"], err)
+		}
+
+		compile(t, fmt.Sprintf("#let v = yaml(\"data.yaml\").value\n#assert.eq(str(v).codepoints().map(str.to-unicode), %s)\n", codepoints(value)),
+			map[string][]byte{"data.yaml": file})
+	})
+}
+
+// compile compiles source with the typst CLI, along with files, and fails the
+// test on errors such as a failed assertion. It does nothing when typst is
+// not installed.
+func compile(t *testing.T, source string, files map[string][]byte) {
+	t.Helper()
+	binary, err := exec.LookPath("typst")
+	if err != nil {
+		return
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.typ"), []byte(source), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// #nosec G204 - the binary is typst from PATH and the arguments are fixed
+	cmd := exec.CommandContext(t.Context(), binary, "compile", "main.typ", "main.pdf")
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("typst compile failed: %v\n%s\n--- main.typ ---\n%s", err, output, source)
+	}
+}
+
+// codepoints renders the code points of s as a Typst array.
+func codepoints(s string) string {
+	var items []string
+	for _, r := range s {
+		items = append(items, strconv.Itoa(int(r)))
+	}
+	if len(items) == 0 {
+		return "()"
+	}
+	return "(" + strings.Join(items, ", ") + ",)"
+}
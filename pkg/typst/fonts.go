@@ -0,0 +1,186 @@
+package typst
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf16"
+)
+
+// EmbeddedFonts are the families built into the Typst CLI, available even
+// when system fonts are ignored.
+var EmbeddedFonts = []string{
+	"New Computer Modern",
+	"New Computer Modern Math",
+	"Libertinus Serif",
+	"DejaVu Sans Mono",
+}
+
+// fontExtensions are the font files Typst loads from a font path.
+var fontExtensions = []string{".ttf", ".otf", ".ttc", ".otc"}
+
+// Name table IDs of the family names, in order of preference.
+const (
+	nameTypographicFamily = 16
+	nameFamily            = 1
+)
+
+var errInvalidFont = errors.New("not an OpenType font")
+
+// FontFamilies returns the families of the fonts under dir, sorted and
+// without duplicates. A directory that does not exist has no fonts.
+func FontFamilies(dir string) ([]string, error) {
+	var families []string
+	err := filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && file == dir {
+			return fs.SkipAll
+		}
+		if err != nil || entry.IsDir() || !slices.Contains(fontExtensions, strings.ToLower(filepath.Ext(file))) {
+			return err
+		}
+		// #nosec G304 - file is inside the font directory
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		fileFamilies, err := fontFamilies(data)
+		if err != nil {
+			return fmt.Errorf("failed to read font %s: %w", file, err)
+		}
+		families = append(families, fileFamilies...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(families)
+	return slices.Compact(families), nil
+}
+
+// HasFont reports whether family is among families. Typst matches family
+// names case-insensitively.
+func HasFont(families []string, family string) bool {
+	return slices.ContainsFunc(families, func(name string) bool { return strings.EqualFold(name, family) })
+}
+
+// fontFamilies returns the family of each font in an OpenType file or
+// collection.
+func fontFamilies(data []byte) ([]string, error) {
+	if len(data) < 12 {
+		return nil, errInvalidFont
+	}
+	if string(data[:4]) != "ttcf" {
+		family, err := fontFamily(data, 0)
+		return []string{family}, err
+	}
+
+	count := int(binary.BigEndian.Uint32(data[8:12]))
+	if len(data) < 12+4*count {
+		return nil, errInvalidFont
+	}
+	families := make([]string, 0, count)
+	for i := range count {
+		family, err := fontFamily(data, int(binary.BigEndian.Uint32(data[12+4*i:])))
+		if err != nil {
+			return nil, err
+		}
+		families = append(families, family)
+	}
+	return families, nil
+}
+
+// fontFamily reads the family name of the font whose table directory starts
+// at offset.
+func fontFamily(data []byte, offset int) (string, error) {
+	if offset < 0 || len(data) < offset+12 {
+		return "", errInvalidFont
+	}
+	switch string(data[offset : offset+4]) {
+	case "\x00\x01\x00\x00", "OTTO", "true":
+	default:
+		return "", errInvalidFont
+	}
+
+	tables := int(binary.BigEndian.Uint16(data[offset+4:]))
+	for i := range tables {
+		record := offset + 12 + 16*i
+		if len(data) < record+16 {
+			return "", errInvalidFont
+		}
+		if string(data[record:record+4]) != "name" {
+			continue
+		}
+		start := int(binary.BigEndian.Uint32(data[record+8:]))
+		length := int(binary.BigEndian.Uint32(data[record+12:]))
+		if start < 0 || length < 0 || len(data) < start+length {
+			return "", errInvalidFont
+		}
+		return familyName(data[start : start+length])
+	}
+	return "", fmt.Errorf("font has no name table")
+}
+
+// familyName picks the family from a name table, preferring the typographic
+// family and English Windows names.
+func familyName(table []byte) (string, error) {
+	if len(table) < 6 {
+		return "", errInvalidFont
+	}
+	count := int(binary.BigEndian.Uint16(table[2:]))
+	storage := int(binary.BigEndian.Uint16(table[4:]))
+
+	var best string
+	bestRank := 0
+	for i := range count {
+		record := 6 + 12*i
+		if len(table) < record+12 {
+			return "", errInvalidFont
+		}
+		platform := binary.BigEndian.Uint16(table[record:])
+		language := binary.BigEndian.Uint16(table[record+4:])
+		nameID := binary.BigEndian.Uint16(table[record+6:])
+		length := int(binary.BigEndian.Uint16(table[record+8:]))
+		start := storage + int(binary.BigEndian.Uint16(table[record+10:]))
+		if nameID != nameTypographicFamily && nameID != nameFamily || len(table) < start+length {
+			continue
+		}
+
+		var name string
+		switch platform {
+		case 0, 3:
+			name = decodeUTF16(table[start : start+length])
+		case 1:
+			name = string(table[start : start+length])
+		default:
+			continue
+		}
+
+		rank := 1
+		if nameID == nameTypographicFamily {
+			rank += 2
+		}
+		if platform == 3 && language == 0x409 {
+			rank++
+		}
+		if name != "" && rank > bestRank {
+			best, bestRank = name, rank
+		}
+	}
+	if best == "" {
+		return "", fmt.Errorf("font has no family name")
+	}
+	return best, nil
+}
+
+func decodeUTF16(data []byte) string {
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = binary.BigEndian.Uint16(data[2*i:])
+	}
+	return string(utf16.Decode(units))
+}
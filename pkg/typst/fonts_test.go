@@ -0,0 +1,96 @@
+package typst_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/typst"
+)
+
+func TestFontFamilies(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"Roboto-Regular.ttf":   font(t, "Roboto", ""),
+		"Roboto-Bold.ttf":      font(t, "Roboto", ""),
+		"sub/PTSans-Light.otf": font(t, "PT Sans Light", "PT Sans"),
+		"README.md":            []byte("not a font"),
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, content, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	families, err := typst.FontFamilies(dir)
+	if err != nil {
+		t.Fatalf("FontFamilies failed: %v", err)
+	}
+	if !slices.Equal(families, []string{"PT Sans", "Roboto"}) {
+		t.Errorf("Unexpected families: %v", families)
+	}
+	if !typst.HasFont(families, "roboto") || typst.HasFont(families, "Lato") {
+		t.Error("HasFont should match family names case-insensitively")
+	}
+
+	if families, err := typst.FontFamilies(filepath.Join(dir, "missing")); err != nil || len(families) != 0 {
+		t.Errorf("Expected no fonts in a missing directory, got %v, %v", families, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "broken.ttf"), []byte("not a font at all"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := typst.FontFamilies(dir); err == nil {
+		t.Error("Expected an error for a file that is not a font")
+	}
+}
+
+// font builds a minimal OpenType font with a name table holding family and,
+// if not empty, a typographic family.
+func font(t *testing.T, family, typographic string) []byte {
+	t.Helper()
+	type name struct {
+		id    uint16
+		value string
+	}
+	names := []name{{1, family}}
+	if typographic != "" {
+		names = append(names, name{16, typographic})
+	}
+
+	var records, storage bytes.Buffer
+	for _, n := range names {
+		encoded := utf16.Encode([]rune(n.value))
+		for _, value := range []uint16{3, 1, 0x409, n.id, uint16(2 * len(encoded)), uint16(storage.Len())} {
+			_ = binary.Write(&records, binary.BigEndian, value)
+		}
+		_ = binary.Write(&storage, binary.BigEndian, encoded)
+	}
+	var table bytes.Buffer
+	for _, value := range []uint16{0, uint16(len(names)), uint16(6 + records.Len())} {
+		_ = binary.Write(&table, binary.BigEndian, value)
+	}
+	table.Write(records.Bytes())
+	table.Write(storage.Bytes())
+
+	var data bytes.Buffer
+	data.Write([]byte{0, 1, 0, 0})
+	for _, value := range []uint16{1, 16, 0, 0} {
+		_ = binary.Write(&data, binary.BigEndian, value)
+	}
+	data.WriteString("name")
+	for _, value := range []uint32{0, 12 + 16, uint32(table.Len())} {
+		_ = binary.Write(&data, binary.BigEndian, value)
+	}
+	data.Write(table.Bytes())
+	return data.Bytes()
+}
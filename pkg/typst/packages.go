@@ -0,0 +1,233 @@
+// Package typst holds what the generators need to know about Typst: how to
+// clean values for the data files templates read, and the packages and fonts
+// templates use, so that compilations work from local directories without
+// network access or system fonts.
+package typst
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DefaultRegistry is the Typst package registry packages are fetched from.
+const DefaultRegistry = "https://packages.typst.org"
+
+// RegistryNamespace is the only namespace the registry serves. Packages in
+// other namespaces, such as @local, exist only in the package directory.
+const RegistryNamespace = "preview"
+
+// maxPackageSize bounds the unpacked size of a fetched package.
+const maxPackageSize = 64 << 20
+
+var (
+	importPattern  = regexp.MustCompile(`import\s+"@([a-z][a-z0-9-]*)/([A-Za-z0-9_-]+):([0-9]+\.[0-9]+\.[0-9]+)"`)
+	packagePattern = regexp.MustCompile(`^@([a-z][a-z0-9-]*)/([A-Za-z0-9_-]+):([0-9]+\.[0-9]+\.[0-9]+)$`)
+)
+
+// Package is a versioned package such as @preview/scienceicons:0.1.0.
+type Package struct {
+	Namespace string
+	Name      string
+	Version   string
+}
+
+// ParsePackage parses a package written as in an import.
+func ParsePackage(spec string) (Package, error) {
+	match := packagePattern.FindStringSubmatch(spec)
+	if match == nil {
+		return Package{}, fmt.Errorf("invalid package %q", spec)
+	}
+	return Package{Namespace: match[1], Name: match[2], Version: match[3]}, nil
+}
+
+func (p Package) String() string {
+	return "@" + p.Namespace + "/" + p.Name + ":" + p.Version
+}
+
+// Dir returns the package's directory in a package path, laid out as Typst's
+// --package-path expects.
+func (p Package) Dir(root string) string {
+	return filepath.Join(root, p.Namespace, p.Name, p.Version)
+}
+
+// Imports returns the packages a Typst source imports, in order of first
+// appearance.
+func Imports(source []byte) []Package {
+	var packages []Package
+	for _, match := range importPattern.FindAllSubmatch(source, -1) {
+		pkg := Package{Namespace: string(match[1]), Name: string(match[2]), Version: string(match[3])}
+		if !slices.Contains(packages, pkg) {
+			packages = append(packages, pkg)
+		}
+	}
+	return packages
+}
+
+// Missing returns the packages that sources import, directly or through
+// other packages, and that root does not contain.
+func Missing(root string, sources [][]byte) ([]Package, error) {
+	var queue []Package
+	for _, source := range sources {
+		queue = append(queue, Imports(source)...)
+	}
+
+	var missing []Package
+	seen := make(map[Package]bool)
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+		if seen[pkg] {
+			continue
+		}
+		seen[pkg] = true
+
+		if _, err := os.Stat(filepath.Join(pkg.Dir(root), "typst.toml")); err != nil {
+			missing = append(missing, pkg)
+			continue
+		}
+		imports, err := packageImports(pkg.Dir(root))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read package %s: %w", pkg, err)
+		}
+		queue = append(queue, imports...)
+	}
+	return missing, nil
+}
+
+// packageImports returns the imports of every Typst file in a package.
+func packageImports(dir string) ([]Package, error) {
+	var imports []Package
+	// The package directory itself may be a symlink, so walk its target
+	err := filepath.WalkDir(dir+string(filepath.Separator), func(file string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || filepath.Ext(file) != ".typ" {
+			return err
+		}
+		// #nosec G304 - file is inside the package directory
+		source, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		imports = append(imports, Imports(source)...)
+		return nil
+	})
+	return imports, err
+}
+
+// Fetch downloads a package from registry and unpacks it into root. The
+// package is unpacked next to its final directory and moved into place once
+// complete, so an interrupted fetch leaves nothing behind.
+func Fetch(ctx context.Context, client *http.Client, registry, root string, pkg Package) error {
+	if pkg.Namespace != RegistryNamespace {
+		return fmt.Errorf("%s is not in the registry and must be placed in the package directory", pkg)
+	}
+	url := fmt.Sprintf("%s/%s/%s-%s.tar.gz", strings.TrimSuffix(registry, "/"), pkg.Namespace, pkg.Name, pkg.Version)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", pkg, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", pkg, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch %s: registry returned %s", pkg, resp.Status)
+	}
+
+	parent := filepath.Dir(pkg.Dir(root))
+	if err := os.MkdirAll(parent, 0o750); err != nil {
+		return fmt.Errorf("failed to create package directory: %w", err)
+	}
+	tmp, err := os.MkdirTemp(parent, ".fetch-*")
+	if err != nil {
+		return fmt.Errorf("failed to create package directory: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := unpack(resp.Body, tmp); err != nil {
+		return fmt.Errorf("failed to unpack %s: %w", pkg, err)
+	}
+	if _, err := os.Stat(filepath.Join(tmp, "typst.toml")); err != nil {
+		return fmt.Errorf("package %s has no typst.toml", pkg)
+	}
+	if err := os.Rename(tmp, pkg.Dir(root)); err != nil {
+		return fmt.Errorf("failed to install %s: %w", pkg, err)
+	}
+	return nil
+}
+
+// unpack extracts a gzipped tar archive into dir. Entries that would land
+// outside dir, links and oversized archives are rejected.
+func unpack(r io.Reader, dir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	archive := tar.NewReader(gz)
+	var total int64
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := path.Clean(strings.TrimPrefix(header.Name, "./"))
+		if name == "." {
+			continue
+		}
+		if !fs.ValidPath(name) {
+			return fmt.Errorf("invalid path %q in archive", header.Name)
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o750); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			total += header.Size
+			if total > maxPackageSize {
+				return fmt.Errorf("archive is larger than %d bytes", maxPackageSize)
+			}
+			if err := writeFile(target, archive, header.Size); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported entry %q in archive", header.Name)
+		}
+	}
+}
+
+func writeFile(target string, r io.Reader, size int64) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return err
+	}
+	// #nosec G304 - target is checked to be inside the package directory
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o640)
+	if err != nil {
+		return err
+	}
+	if _, err := io.CopyN(file, r, size); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,172 @@
+package typst_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AlexTLDR/mycv.quest/pkg/typst"
+)
+
+func TestParsePackage(t *testing.T) {
+	t.Parallel()
+	pkg, err := typst.ParsePackage("@preview/basic-resume:0.2.9")
+	if err != nil {
+		t.Fatalf("ParsePackage failed: %v", err)
+	}
+	if pkg.Namespace != "preview" || pkg.Name != "basic-resume" || pkg.Version != "0.2.9" {
+		t.Errorf("Unexpected package: %+v", pkg)
+	}
+	if pkg.String() != "@preview/basic-resume:0.2.9" {
+		t.Errorf("Unexpected string: %s", pkg)
+	}
+
+	for _, spec := range []string{"preview/basic-resume:0.2.9", "@preview/basic-resume", "@preview/../x:1.0.0"} {
+		if _, err := typst.ParsePackage(spec); err == nil {
+			t.Errorf("Expected an error for %q", spec)
+		}
+	}
+}
+
+func TestImports(t *testing.T) {
+	t.Parallel()
+	source := []byte(`#import "@preview/basic-resume:0.2.9": *
+#import "utils.typ": helper
+#import "@preview/scienceicons:0.1.0": orcid-icon
+#import "@preview/basic-resume:0.2.9": resume`)
+
+	imports := typst.Imports(source)
+	if len(imports) != 2 || imports[0].Name != "basic-resume" || imports[1].Name != "scienceicons" {
+		t.Errorf("Unexpected imports: %v", imports)
+	}
+}
+
+func TestMissing(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	writePackage(t, root, "@preview/outer:1.0.0", `#import "@preview/inner:1.0.0": *`)
+
+	missing, err := typst.Missing(root, [][]byte{[]byte(`#import "@preview/outer:1.0.0": *`)})
+	if err != nil {
+		t.Fatalf("Missing failed: %v", err)
+	}
+	if len(missing) != 1 || missing[0].Name != "inner" {
+		t.Fatalf("Expected the transitive import to be missing, got %v", missing)
+	}
+
+	writePackage(t, root, "@preview/inner:1.0.0", "#let x = 1")
+	missing, err = typst.Missing(root, [][]byte{[]byte(`#import "@preview/outer:1.0.0": *`)})
+	if err != nil || len(missing) != 0 {
+		t.Errorf("Expected nothing missing, got %v, %v", missing, err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+	archive := tarball(t, map[string]string{
+		"typst.toml": "[package]\nname = \"icons\"\n",
+		"lib.typ":    "#let icon = 1",
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/preview/icons-0.1.0.tar.gz" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(archive)
+	}))
+	defer server.Close()
+
+	root := t.TempDir()
+	pkg, _ := typst.ParsePackage("@preview/icons:0.1.0")
+	if err := typst.Fetch(context.Background(), server.Client(), server.URL, root, pkg); err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if content, err := os.ReadFile(filepath.Join(pkg.Dir(root), "lib.typ")); err != nil || string(content) != "#let icon = 1" {
+		t.Errorf("Package was not unpacked: %q, %v", content, err)
+	}
+
+	unknown, _ := typst.ParsePackage("@preview/unknown:0.1.0")
+	err := typst.Fetch(context.Background(), server.Client(), server.URL, root, unknown)
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Expected a not found error, got: %v", err)
+	}
+
+	// Local packages never come from the registry, even under a served name
+	local, _ := typst.ParsePackage("@local/icons:0.1.0")
+	err = typst.Fetch(context.Background(), server.Client(), server.URL, root, local)
+	if err == nil || !strings.Contains(err.Error(), "not in the registry") {
+		t.Errorf("Expected a local package error, got: %v", err)
+	}
+}
+
+func TestFetchRejectsEscapingPaths(t *testing.T) {
+	t.Parallel()
+	archive := tarball(t, map[string]string{
+		"typst.toml":   "[package]\n",
+		"../../escape": "outside",
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(archive)
+	}))
+	defer server.Close()
+
+	root := t.TempDir()
+	pkg, _ := typst.ParsePackage("@preview/evil:0.1.0")
+	if err := typst.Fetch(context.Background(), server.Client(), server.URL, root, pkg); err == nil {
+		t.Fatal("Expected an error for an archive escaping the package directory")
+	}
+	if _, err := os.Stat(pkg.Dir(root)); !os.IsNotExist(err) {
+		t.Errorf("Expected no package directory, got: %v", err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(pkg.Dir(root)))
+	if len(entries) != 0 {
+		t.Errorf("Expected the partial fetch to be removed, found %d entries", len(entries))
+	}
+}
+
+func writePackage(t *testing.T, root, spec, source string) {
+	t.Helper()
+	pkg, err := typst.ParsePackage(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(pkg.Dir(root), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(pkg.Dir(root), "typst.toml"), []byte("[package]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(pkg.Dir(root), "lib.typ"), []byte(source), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func tarball(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	archive := tar.NewWriter(gz)
+	for name, content := range files {
+		header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := archive.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := archive.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
@@ -4,31 +4,6 @@ import (
 	"strings"
 )
 
-// SanitizeForTypst sanitizes user input to prevent Typst syntax errors.
-func SanitizeForTypst(input string) string {
-	if input == "" {
-		return input
-	}
-
-	result := input
-
-	// Apply backslash escaping first to avoid double-escaping
-	result = strings.ReplaceAll(result, `\`, `\\`)
-
-	// Then apply other escapes
-	result = strings.ReplaceAll(result, `"`, `\"`)  // Escape double quotes for Typst strings
-	result = strings.ReplaceAll(result, `$`, `\$`)  // Escape dollar sign (Typst uses $ for math)
-	result = strings.ReplaceAll(result, `#`, `\#`)  // Escape hash (Typst uses # for functions)
-	result = strings.ReplaceAll(result, "`", "\\`") // Escape backticks (using regular string literals)
-
-	return result
-}
-
-// SanitizeFormValue is a convenience function for sanitizing HTTP form values.
-func SanitizeFormValue(value string) string {
-	return SanitizeForTypst(strings.TrimSpace(value))
-}
-
 // NormalizeURL ensures a URL has a proper protocol prefix.
 func NormalizeURL(url string) string {
 	if url == "" {
@@ -42,3 +17,12 @@ func NormalizeURL(url string) string {
 
 	return url
 }
+
+// LinkedInURL returns the profile URL of a LinkedIn handle, or the URL itself
+// when it already points at LinkedIn.
+func LinkedInURL(handle string) string {
+	if handle == "" || strings.Contains(handle, "linkedin.com") {
+		return NormalizeURL(handle)
+	}
+	return "https://www.linkedin.com/in/" + strings.TrimSpace(handle)
+}
@@ -15,9 +15,11 @@
   accent-color: "#000000",
   font: "New Computer Modern",
   paper: "us-letter",
+  margin: 0.5in,
   author-font-size: 20pt,
   font-size: 10pt,
   lang: "en",
+  region: none,
   body,
 ) = {
 
@@ -30,13 +32,14 @@
     font: font,
     size: font-size,
     lang: lang,
+    region: region,
     // Disable ligatures so ATS systems do not get confused when parsing fonts.
     ligatures: false
   )
 
   // Reccomended to have 0.5in margin on all sides
   set page(
-    margin: (0.5in),
+    margin: margin,
     paper: paper,
   )
 
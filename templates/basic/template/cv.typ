@@ -0,0 +1,73 @@
+// Entry point of the basic template. The Go renderer writes the CV to
+// data.json; this file only lays it out, so user input is never Typst code.
+#import "@local/basic-resume:0.2.9": *
+#import "richtext.typ": bullets
+
+#let cv = json("data.json")
+
+#show: resume.with(
+  author: cv.name,
+  location: cv.location,
+  email: cv.email,
+  github: cv.github,
+  linkedin: cv.linkedin,
+  phone: cv.phone,
+  personal-site: cv.personal_site,
+  accent-color: cv.accent_color,
+  font: cv.font,
+  paper: cv.paper,
+  margin: if "margin_mm" in cv { cv.margin_mm * 1mm } else { 0.5in },
+  lang: cv.lang,
+  region: cv.at("region", default: none),
+  author-position: left,
+  personal-info-position: left,
+)
+
+== Education
+
+#for entry in cv.education [
+  #edu(
+    institution: entry.institution,
+    location: entry.location,
+    dates: dates-helper(start-date: entry.from, end-date: entry.to),
+    degree: entry.degree,
+  )
+  #bullets(entry.description)
+
+]
+
+== Work Experience
+
+#for entry in cv.work [
+  #work(
+    title: entry.title,
+    location: entry.location,
+    company: entry.company,
+    dates: dates-helper(start-date: entry.from, end-date: entry.to),
+  )
+  #bullets(entry.description)
+
+]
+
+== Projects
+
+#for entry in cv.projects [
+  #project(
+    name: entry.name,
+    role: entry.role,
+    dates: if entry.from != "" { dates-helper(start-date: entry.from, end-date: entry.to) } else { "" },
+    url: entry.url,
+  )
+  #bullets(entry.description)
+
+]
+
+#if cv.programming_languages != "" or cv.technologies != "" [
+  == Skills
+  #if cv.programming_languages != "" [
+    - *Programming Languages*: #cv.programming_languages
+  ]
+  #if cv.technologies != "" [
+    - *Technologies*: #cv.technologies
+  ]
+]
@@ -1,4 +1,4 @@
-#import "@preview/basic-resume:0.2.8": *
+#import "@local/basic-resume:0.2.9": *
 
 // Put your personal information here, replacing mine
 #let name = "Stephen Xu"
@@ -39,7 +39,7 @@ func Index(templates []CVTemplate) templ.Component {
 			templ_7745c5c3_Var1 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<!doctype html><html lang=\"en\"><head><meta charset=\"UTF-8\"><meta name=\"viewport\" content=\"width=device-width, initial-scale=1.0\"><title>mycv.quest</title><link rel=\"icon\" type=\"image/svg+xml\" href=\"/assets/images/logo.svg\"><link rel=\"stylesheet\" href=\"/assets/css/output.css\"><style>\n\t\t\t\t.pdf-preview {\n\t\t\t\t\taspect-ratio: 3/4;\n\t\t\t\t\tbackground: #f8f9fa;\n\t\t\t\t\tborder: 1px solid #e9ecef;\n\t\t\t\t}\n\t\t\t\t.template-card {\n\t\t\t\t\ttransition: all 0.3s ease;\n\t\t\t\t}\n\t\t\t\t.template-card:hover {\n\t\t\t\t\ttransform: translateY(-4px);\n\t\t\t\t\tbox-shadow: 0 20px 25px -5px rgba(0, 0, 0, 0.1), 0 10px 10px -5px rgba(0, 0, 0, 0.04);\n\t\t\t\t}\n\t\t\t\t.footer-link:hover {\n\t\t\t\t\tcolor: white !important;\n\t\t\t\t}\n\t\t\t\t.footer-container:hover .heart {\n\t\t\t\t\tcolor: red !important;\n\t\t\t\t}\n\t\t\t\t.heart {\n\t\t\t\t\ttransition: color 0.3s ease;\n\t\t\t\t}\n\t\t\t\t.coming-soon-stamp {\n\t\t\t\t\tposition: absolute;\n\t\t\t\t\ttop: 20px;\n\t\t\t\t\tright: -35px;\n\t\t\t\t\tbackground: #10B981;\n\t\t\t\t\tcolor: white;\n\t\t\t\t\tpadding: 8px 40px;\n\t\t\t\t\tfont-size: 12px;\n\t\t\t\t\tfont-weight: bold;\n\t\t\t\t\ttransform: rotate(45deg);\n\t\t\t\t\tbox-shadow: 0 2px 8px rgba(0,0,0,0.2);\n\t\t\t\t\ttext-transform: uppercase;\n\t\t\t\t\tletter-spacing: 0.5px;\n\t\t\t\t}\n\t\t\t</style></head><body class=\"min-h-screen\" style=\"background-color: #006699;\"><header class=\"shadow-sm border-b\" style=\"background-color: #0099CC;\"><div class=\"max-w-7xl mx-auto px-4 sm:px-6 lg:px-8 py-6\"><table width=\"100%\"><tr><td align=\"left\"><h1 class=\"text-3xl font-bold text-gray-900\">mycv.quest</h1></td><td align=\"right\"><img src=\"./assets/images/logo.svg\" alt=\"mycv.quest Logo\" width=\"96\" height=\"96\"></td></tr></table><p class=\"mt-2 text-gray-600\">Choose from our professional CV templates</p></div></header><main class=\"max-w-7xl mx-auto px-4 sm:px-6 lg:px-8 py-12\"><div class=\"grid grid-cols-1 md:grid-cols-2 lg:grid-cols-3 gap-8\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<!doctype html><html lang=\"en\"><head><meta charset=\"UTF-8\"><meta name=\"viewport\" content=\"width=device-width, initial-scale=1.0\"><title>mycv.quest</title><link rel=\"icon\" type=\"image/svg+xml\" href=\"/assets/images/logo.svg\"><link rel=\"stylesheet\" href=\"/assets/css/output.css\"><style>\n\t\t\t\t.pdf-preview {\n\t\t\t\t\taspect-ratio: 3/4;\n\t\t\t\t\tbackground: #f8f9fa;\n\t\t\t\t\tborder: 1px solid #e9ecef;\n\t\t\t\t}\n\t\t\t\t.template-card {\n\t\t\t\t\ttransition: all 0.3s ease;\n\t\t\t\t}\n\t\t\t\t.template-card:hover {\n\t\t\t\t\ttransform: translateY(-4px);\n\t\t\t\t\tbox-shadow: 0 20px 25px -5px rgba(0, 0, 0, 0.1), 0 10px 10px -5px rgba(0, 0, 0, 0.04);\n\t\t\t\t}\n\t\t\t\t.footer-link:hover {\n\t\t\t\t\tcolor: white !important;\n\t\t\t\t}\n\t\t\t\t.footer-container:hover .heart {\n\t\t\t\t\tcolor: red !important;\n\t\t\t\t}\n\t\t\t\t.heart {\n\t\t\t\t\ttransition: color 0.3s ease;\n\t\t\t\t}\n\t\t\t\t.coming-soon-stamp {\n\t\t\t\t\tposition: absolute;\n\t\t\t\t\ttop: 20px;\n\t\t\t\t\tright: -35px;\n\t\t\t\t\tbackground: #10B981;\n\t\t\t\t\tcolor: white;\n\t\t\t\t\tpadding: 8px 40px;\n\t\t\t\t\tfont-size: 12px;\n\t\t\t\t\tfont-weight: bold;\n\t\t\t\t\ttransform: rotate(45deg);\n\t\t\t\t\tbox-shadow: 0 2px 8px rgba(0,0,0,0.2);\n\t\t\t\t\ttext-transform: uppercase;\n\t\t\t\t\tletter-spacing: 0.5px;\n\t\t\t\t}\n\t\t\t</style></head><body class=\"min-h-screen\" style=\"background-color: #006699;\"><header class=\"shadow-sm border-b\" style=\"background-color: #0099CC;\"><div class=\"max-w-7xl mx-auto px-4 sm:px-6 lg:px-8 py-6\"><table width=\"100%\"><tr><td align=\"left\"><h1 class=\"text-3xl font-bold text-gray-900\">mycv.quest</h1></td><td align=\"right\"><img src=\"./assets/images/logo.svg\" alt=\"mycv.quest Logo\" width=\"96\" height=\"96\"></td></tr></table><p class=\"mt-2 text-gray-600\">Choose from our professional CV templates or continue one of <a href=\"/drafts\" class=\"underline\">your drafts</a></p></div></header><main class=\"max-w-7xl mx-auto px-4 sm:px-6 lg:px-8 py-12\"><div class=\"grid grid-cols-1 md:grid-cols-2 lg:grid-cols-3 gap-8\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -106,12 +106,12 @@
   )
 }
 
-#let headerRibbon(color, content) = {
+#let headerRibbon(color, content, margin: page-margin) = {
   block(
     width: 100%,
     fill: color,
     inset: (
-      left: page-margin,
+      left: margin,
       right: 8pt,
       top: 8pt,
       bottom: 8pt,
@@ -120,15 +120,16 @@
   )
 }
 
-#let header(author, job-title, bio: none, avatar: none, contact-options: ()) = {
+#let header(author, job-title, bio: none, avatar: none, contact-options: (), margin: page-margin) = {
   grid(
     columns: 1,
     rows: (auto, auto),
     headerRibbon(
       theme.primary,
-      headline(author, job-title, bio, avatar: avatar)
+      headline(author, job-title, bio, avatar: avatar),
+      margin: margin,
     ),
-    headerRibbon(theme.secondary, contactDetails(contact-options))
+    headerRibbon(theme.secondary, contactDetails(contact-options), margin: margin)
   )
 }
 
@@ -216,6 +217,15 @@
   // A list of contact options, defaults to an empty set.
   contact-options: (),
 
+  // The font family of the resume.
+  font: "Roboto",
+
+  // The paper size, the margin around the content and the text language.
+  paper: "a4",
+  margin: page-margin,
+  lang: "en",
+  region: none,
+
   // The resume's content.
   body
 ) = {
@@ -223,16 +233,16 @@
   set document(title: "Resume of " + author, author: author)
 
   // Set the body font.
-  set text(font: "Roboto", size: text-size.normal)
+  set text(font: font, size: text-size.normal, lang: lang, region: region)
 
   // Configure the page.
   set page(
-    paper: "a4",
+    paper: paper,
     margin: (
       top: 0cm,
       left: 0cm,
       right: 0cm,
-      bottom: 1cm,
+      bottom: margin + 1cm - page-margin,
     ),
   )
 
@@ -259,7 +269,7 @@
     show link: it => [
       #it #linkIcon()
     ]
-    header(author, job-title, bio: bio, avatar: avatar, contact-options: contact-options)
+    header(author, job-title, bio: bio, avatar: avatar, contact-options: contact-options, margin: margin)
   }
 
   // Main content
@@ -268,8 +278,8 @@
       #it #linkIcon(color: theme.accentColor)
     ]
     pad(
-      left: page-margin,
-      right: page-margin,
+      left: margin,
+      right: margin,
       top: 8pt
     )[#columns(2, body)]
   }
@@ -0,0 +1,95 @@
+// Entry point of the modern template. The Go renderer writes the CV to
+// data.json; this file only lays it out, so user input is never Typst code.
+#import "@local/modern-resume:0.1.0": modern-resume, experience-work, experience-edu, project, pill
+#import "richtext.typ": bullets
+
+#let cv = json("data.json")
+
+// optional turns an empty string into none, so the template leaves it out.
+#let optional(value) = if value == "" { none } else { value }
+
+#show: modern-resume.with(
+  author: cv.author,
+  job-title: cv.job_title,
+  bio: cv.bio,
+  avatar: if cv.avatar != "" { image(cv.avatar) } else { none },
+  font: cv.font,
+  paper: cv.paper,
+  margin: if "margin_mm" in cv { cv.margin_mm * 1mm } else { 16pt },
+  lang: cv.lang,
+  region: cv.at("region", default: none),
+  contact-options: cv.contacts.map(contact => (
+    contact.key,
+    if contact.url != "" { link(contact.url, contact.text) } else { contact.text },
+  )).to-dict(),
+)
+
+== Education
+
+#for entry in cv.education [
+  #experience-edu(
+    title: entry.title,
+    subtitle: entry.subtitle,
+    task-description: bullets(entry.description),
+    ..entry.dates,
+  )
+
+]
+
+== Work experience
+
+#for entry in cv.work [
+  #experience-work(
+    title: entry.title,
+    subtitle: entry.subtitle,
+    facility-description: entry.facility_description,
+    task-description: bullets(entry.description),
+    ..entry.dates,
+  )
+
+]
+
+#colbreak()
+
+#if cv.skills.len() > 0 [
+  == Skills
+
+  #for skill in cv.skills [#pill(skill, fill: true)]
+
+]
+
+== Projects
+
+#for entry in cv.projects [
+  #project(
+    title: entry.title,
+    subtitle: optional(entry.subtitle),
+    description: bullets(entry.description),
+    ..entry.dates,
+  )
+
+]
+
+== Certificates
+
+#for entry in cv.certificates [
+  #project(
+    title: entry.title,
+    subtitle: optional(entry.subtitle),
+    ..entry.dates,
+  )
+
+]
+
+#if cv.languages.len() > 0 [
+  == Languages
+
+  #for language in cv.languages [#pill(language)]
+
+]
+
+#if cv.interests.len() > 0 [
+  == Interests
+
+  #for interest in cv.interests [#pill(interest)]
+]
@@ -1,4 +1,4 @@
-#import "@preview/modern-resume:0.1.0": modern-resume, experience-work, experience-edu, project, pill
+#import "@local/modern-resume:0.1.0": modern-resume, experience-work, experience-edu, project, pill
 
 #show: modern-resume.with(
   author: "John Doe",
@@ -1,4 +1,5 @@
 #import "vantage-typst.typ": vantage, term, skill, styled-link
+#import "richtext.typ": bullets, paragraph
 #let configuration = yaml("configuration.yaml")
 
 #vantage(
@@ -13,6 +14,11 @@
     (name: "location", link: "", display: configuration.contacts.address)
   ),
   tagline: (configuration.tagline),
+  font: configuration.at("font", default: "PT Sans"),
+  paper: configuration.at("paper", default: "a4"),
+  margin: configuration.at("margin_mm", default: 12) * 1mm,
+  lang: configuration.at("lang", default: "en"),
+  region: configuration.at("region", default: none),
   [
 
     == Experience
@@ -22,9 +28,7 @@
       _#link(job.company.link)[#job.company.name]_ - #styled-link(job.product.link)[#job.product.name] \
       #term[#job.from --- #job.to][#job.location]
 
-      #for point in job.description [
-        - #point
-      ]
+      #bullets(job.description)
     ]
 
   ],
@@ -76,7 +80,7 @@
     #for achievement in configuration.achievements [
       === #achievement.name
       \
-      #achievement.description
+      #paragraph(achievement.description)
     ]
 
   ]
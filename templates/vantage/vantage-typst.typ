@@ -87,6 +87,11 @@
   position: "",
   links: (),
   tagline: [],
+  font: "PT Sans",
+  paper: "a4",
+  margin: 1.2cm,
+  lang: "en",
+  region: none,
   leftSide,
   rightSide
 ) = {
@@ -94,9 +99,10 @@
     title: name + "'s CV",
     author: name,
   )
-  set text(9.8pt, font: "PT Sans")
+  set text(9.8pt, font: font, lang: lang, region: region)
   set page(
-    margin: (x: 1.2cm, y: 1.2cm),
+    paper: paper,
+    margin: (x: margin, y: margin),
   )
 
   show heading.where(level: 1) : it => text(16pt,[#{it.body} #v(1pt)])